@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/danielyan21/JiraCLI/internal/config"
 	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
@@ -15,19 +13,21 @@ var assignCmd = &cobra.Command{
 
 Examples:
   jira assign PROJ-123 @me          # Assign ticket to self`,
-	Args: cobra.ExactArgs(2),
+	Args: ticketKeyArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		ticketKey := args[0]
-		newAssignee := args[1]
-
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
 		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 2, false)
+		ticketKey := args[0]
+		newAssignee := args[1]
 
-		fmt.Printf("Assigning %s to '%s'...\n", ticketKey, newAssignee)
-		err := client.AssignIssue(ticketKey, newAssignee)
+		ui.Progress("Assigning %s to '%s'...\n", ticketKey, newAssignee)
+		err := client.AssignIssueContext(ctx, ticketKey, newAssignee)
 		ui.FatalIfError(err, "Error updating assignee")
 
-		fmt.Printf("Successfully assigned %s to '%s'\n", ticketKey, newAssignee)
+		ui.Result(ui.ActionJSON{Key: ticketKey, Action: "assign", Assignee: newAssignee},
+			"Successfully assigned %s to '%s'\n", ticketKey, newAssignee)
 	},
 }
 
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach [ticket-key] [file...]",
+	Short: "Attach files to a ticket",
+	Long: `Upload one or more files as attachments on a Jira ticket.
+
+Examples:
+  jira attach PROJ-123 screenshot.png
+  jira attach PROJ-123 logs/*.txt`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 2, true)
+		ticketKey := args[0]
+		paths := args[1:]
+
+		// Fail before uploading anything if a file is missing.
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			ui.FatalIfError(err, "Error reading file")
+			if info.IsDir() {
+				ui.FatalError("%s is a directory", path)
+			}
+		}
+
+		var docs []ui.AttachmentJSON
+		for _, path := range paths {
+			ui.Progress("Uploading %s to %s...\n", filepath.Base(path), ticketKey)
+			attachments, err := uploadFile(ctx, client, ticketKey, path)
+			ui.FatalIfError(err, "Error uploading "+path)
+			for i := range attachments {
+				docs = append(docs, ui.NewAttachmentJSON(&attachments[i]))
+			}
+		}
+
+		if ui.JSONOutput() {
+			ui.PrintJSON(docs)
+			return
+		}
+		fmt.Printf("✅ Attached %d file(s) to %s\n", len(docs), ticketKey)
+	},
+}
+
+func uploadFile(ctx context.Context, client *api.Client, ticketKey, path string) ([]api.Attachment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(path)
+	progress := ui.NewProgressReader(file, name, info.Size())
+	defer progress.Done()
+	return client.AttachFileContext(ctx, ticketKey, name, progress, info.Size())
+}
+
+var attachmentCmd = &cobra.Command{
+	Use:   "attachment",
+	Short: "List and download a ticket's attachments",
+	Long: `List and download the files attached to a Jira ticket.
+
+Use "jira attach" to upload files.
+
+Examples:
+  jira attachment list PROJ-123
+  jira attachment get PROJ-123 screenshot.png
+  jira attachment get PROJ-123 10042 -o ~/Downloads
+  jira attachment get PROJ-123 trace.log -o - | less`,
+}
+
+var attachmentListCmd = &cobra.Command{
+	Use:   "list [ticket-key]",
+	Short: "List a ticket's attachments",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 1, false)
+		ticketKey := args[0]
+
+		ui.Progress("Fetching attachments for %s...\n", ticketKey)
+		attachments, err := client.GetAttachmentsContext(ctx, ticketKey)
+		ui.FatalIfError(err, "Error fetching attachments")
+
+		ui.RenderAttachments(ticketKey, attachments)
+	},
+}
+
+var attachmentGetCmd = &cobra.Command{
+	Use:   "get [ticket-key] [name-or-id]",
+	Short: "Download an attachment",
+	Long: `Download an attachment by file name or ID into a directory (default the
+current one), or to stdout with -o -. Existing files are not overwritten
+unless --force is given.`,
+	Args: ticketKeyArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 2, false)
+		ticketKey, nameOrID := args[0], args[1]
+		outDir, _ := cmd.Flags().GetString("output")
+		force, _ := cmd.Flags().GetBool("force")
+
+		attachments, err := client.GetAttachmentsContext(ctx, ticketKey)
+		ui.FatalIfError(err, "Error fetching attachments")
+		attachment, err := api.FindAttachment(attachments, nameOrID)
+		ui.FatalIfError(err, "Error finding attachment")
+
+		if outDir == "-" {
+			ui.FatalIfError(downloadAttachment(ctx, client, attachment, os.Stdout), "Error downloading attachment")
+			return
+		}
+
+		path := filepath.Join(outDir, filepath.Base(attachment.Filename))
+		if _, err := os.Stat(path); err == nil && !force {
+			ui.FatalError("%s already exists; use --force to overwrite it", path)
+		}
+
+		ui.Progress("Downloading %s (%s)...\n", attachment.Filename, ui.FormatSize(attachment.Size))
+		err = saveAttachment(ctx, client, attachment, path)
+		ui.FatalIfError(err, "Error downloading attachment")
+
+		doc := ui.NewAttachmentJSON(attachment)
+		ui.Result(struct {
+			ui.AttachmentJSON
+			Path string `json:"path"`
+		}{doc, path}, "✅ Saved %s\n", path)
+	},
+}
+
+// saveAttachment downloads into a temporary file next to path and renames
+// it into place, so a failed download never leaves a partial file behind.
+func saveAttachment(ctx context.Context, client *api.Client, attachment *api.Attachment, path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.part")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = downloadAttachment(ctx, client, attachment, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func downloadAttachment(ctx context.Context, client *api.Client, attachment *api.Attachment, w io.Writer) error {
+	body, err := client.DownloadAttachmentContext(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	progress := ui.NewProgressReader(body, attachment.Filename, attachment.Size)
+	defer progress.Done()
+
+	n, err := io.Copy(w, progress)
+	if err != nil {
+		return err
+	}
+	if attachment.Size > 0 && n != attachment.Size {
+		return errors.New("download was cut short")
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(attachCmd, attachmentCmd)
+	attachmentCmd.AddCommand(attachmentListCmd, attachmentGetCmd)
+
+	attachmentGetCmd.Flags().StringP("output", "o", ".", "Directory to save into, or '-' for stdout")
+	attachmentGetCmd.Flags().BoolP("force", "f", false, "Overwrite an existing file")
+}
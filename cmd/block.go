@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/danielyan21/JiraCLI/internal/config"
 	"github.com/danielyan21/JiraCLI/internal/ui"
@@ -14,36 +15,63 @@ var blockCmd = &cobra.Command{
 	Long: `Mark a ticket as "Blocked" and optionally add a comment explaining why.
 
 This is a quick action that updates the ticket status to "Blocked".
-Use the --reason flag to add a comment explaining the blocker.
+Use the --reason flag to add a comment explaining the blocker. When the
+blocker is another ticket, --by also records it as an "is blocked by"
+link, which 'jira unblock' removes again.
 
 Examples:
   jira block PROJ-123
   jira block PROJ-123 --reason "Waiting for API access"
-  jira block PROJ-123 -r "Dependencies not ready"`,
-	Args: cobra.ExactArgs(1),
+  jira block PROJ-123 -r "Dependencies not ready"
+  jira block PROJ-123 --by PROJ-99`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ticketKey := args[0]
-		reason, _ := cmd.Flags().GetString("reason")
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
 		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 1, false)
+		ticketKey := args[0]
+		reason, _ := cmd.Flags().GetString("reason")
+		blockers, _ := cmd.Flags().GetStringArray("by")
 
-		fmt.Printf("Marking %s as blocked...\n", ticketKey)
+		ui.Progress("Marking %s as blocked...\n", ticketKey)
 
-		err := client.UpdateIssueStatus(ticketKey, "Blocked")
+		err := client.UpdateIssueStatusContext(ctx, ticketKey, "Blocked")
 		ui.FatalIfError(err, "Error updating status")
 
+		var warnings []string
+		var linked []string
+		for _, blocker := range blockers {
+			ui.Progress("Linking %s as blocked by %s...\n", ticketKey, blocker)
+			if _, err := client.LinkIssuesContext(ctx, ticketKey, blockedByRelation, blocker); err != nil {
+				ui.Progress("Warning: Could not link %s: %v\n", blocker, err)
+				warnings = append(warnings, fmt.Sprintf("could not link %s: %v", blocker, err))
+				continue
+			}
+			linked = append(linked, blocker)
+		}
+
 		if reason != "" {
-			fmt.Printf("Adding comment...\n")
-			if err := client.AddComment(ticketKey, reason); err != nil {
-				fmt.Printf("Warning: Could not add comment: %v\n", err)
+			ui.Progress("Adding comment...\n")
+			if err := client.AddCommentContext(ctx, ticketKey, reason); err != nil {
+				ui.Progress("Warning: Could not add comment: %v\n", err)
+				warnings = append(warnings, fmt.Sprintf("could not add comment: %v", err))
 			}
 		}
 
-		fmt.Printf("✅ %s is now Blocked\n", ticketKey)
+		doc := ui.ActionJSON{Key: ticketKey, Action: "block", Status: "Blocked", Comment: reason, Warnings: warnings}
+		if len(linked) > 0 {
+			doc.Relation = blockedByRelation
+			doc.Target = strings.Join(linked, ", ")
+			ui.Result(doc, "✅ %s is now Blocked by %s\n", ticketKey, doc.Target)
+			return
+		}
+		ui.Result(doc, "✅ %s is now Blocked\n", ticketKey)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(blockCmd)
 	blockCmd.Flags().StringP("reason", "r", "", "reason for blocking (adds as comment)")
+	blockCmd.Flags().StringArrayP("by", "b", nil, "ticket that blocks this one (adds an \"is blocked by\" link, repeatable)")
 }
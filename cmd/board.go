@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Show the current sprint or a JQL query as a kanban board",
+	Long: `Show tickets as a kanban board, one column per status.
+
+By default this is the current sprint of the board chosen like the sprint
+commands do (--board, default_board, or the default project's board); for
+a Kanban board, it's the board's own filter. Columns and WIP limits come
+from the board's configuration. With --jql any search can be drawn; without
+a board its columns are the statuses found.
+
+Examples:
+  jira board
+  jira board --swimlanes assignee
+  jira board --sprint next --swimlanes epic
+  jira board --jql "project = PROJ AND fixVersion = 2.4"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		flags := cmd.Flags()
+		jql, _ := flags.GetString("jql")
+		sprintRef, _ := flags.GetString("sprint")
+		lanes, _ := flags.GetString("swimlanes")
+		limit, _ := flags.GetInt("limit")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		view := &ui.BoardView{}
+		laneOf, err := swimlaneFunc(ctx, client, lanes)
+		ui.FatalIfError(err, "Invalid flags")
+		view.LaneOf = laneOf
+
+		var board *api.BoardConfiguration
+		boardID, err := resolveBoard(ctx, cmd, client, cfg)
+		if err != nil && jql == "" {
+			ui.FatalIfError(err, "Error finding board")
+		}
+		if err == nil {
+			// Later lookups (sprints) reuse the board instead of resolving it again.
+			flags.Set("board", strconv.Itoa(boardID))
+			board, err = client.GetBoardConfigurationContext(ctx, boardID)
+			ui.FatalIfError(err, "Error fetching board configuration")
+			view.Columns = configuredColumns(board)
+		}
+
+		var query string
+		switch {
+		case jql != "":
+			query = jql
+			view.Title = jql
+		case board.Type == "kanban" && !flags.Changed("sprint"):
+			query = fmt.Sprintf("filter = %s ORDER BY Rank ASC", board.Filter.ID)
+			view.Title = board.Name
+		default:
+			sprints, err := resolveSprints(ctx, cmd, client, cfg, sprintRef)
+			ui.FatalIfError(err, "Error finding sprint")
+			var names []string
+			for _, sprint := range sprints {
+				names = append(names, sprint.Name)
+			}
+			query = sprintJQL(sprints) + " ORDER BY Rank ASC"
+			view.Title = board.Name + " · " + strings.Join(names, ", ")
+		}
+
+		ui.Progress("Fetching tickets...\n")
+		results, err := client.SearchIssuesContext(ctx, query, limit)
+		ui.FatalIfError(err, "Error fetching tickets")
+		view.Issues = results.Issues
+		if len(view.Columns) == 0 {
+			view.Columns = statusColumns(results.Issues)
+		}
+
+		ui.RenderBoard(view)
+	},
+}
+
+func configuredColumns(board *api.BoardConfiguration) []ui.BoardColumn {
+	var columns []ui.BoardColumn
+	for _, column := range board.ColumnConfig.Columns {
+		// Columns without statuses (e.g. an unmapped Backlog) never hold cards.
+		if len(column.Statuses) == 0 {
+			continue
+		}
+		col := ui.BoardColumn{Name: column.Name, Min: column.Min, Max: column.Max}
+		for _, status := range column.Statuses {
+			col.Statuses = append(col.Statuses, status.ID)
+		}
+		columns = append(columns, col)
+	}
+	return columns
+}
+
+// statusCategoryOrder puts to-do statuses first and done statuses last.
+var statusCategoryOrder = map[string]int{"new": 0, "indeterminate": 1, "done": 2}
+
+// statusColumns makes one column per status found, for searches that
+// aren't tied to a board.
+func statusColumns(issues []api.Issue) []ui.BoardColumn {
+	var statuses []api.Status
+	seen := map[string]bool{}
+	for _, issue := range issues {
+		status := issue.Fields.Status
+		if !seen[status.Name] {
+			seen[status.Name] = true
+			statuses = append(statuses, status)
+		}
+	}
+
+	order := func(status api.Status) int {
+		if status.StatusCategory == nil {
+			return 1
+		}
+		if n, ok := statusCategoryOrder[status.StatusCategory.Key]; ok {
+			return n
+		}
+		return 1
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return order(statuses[i]) < order(statuses[j])
+	})
+
+	columns := make([]ui.BoardColumn, len(statuses))
+	for i, status := range statuses {
+		columns[i] = ui.BoardColumn{Name: status.Name, Statuses: []string{status.Name}}
+	}
+	return columns
+}
+
+// swimlaneFunc returns how --swimlanes groups cards, or nil for none.
+func swimlaneFunc(ctx context.Context, client *api.Client, lanes string) (func(*api.Issue) string, error) {
+	switch strings.ToLower(lanes) {
+	case "", "none":
+		return nil, nil
+	case "assignee":
+		return func(issue *api.Issue) string {
+			if issue.Fields.Assignee == nil {
+				return "(Unassigned)"
+			}
+			return issue.Fields.Assignee.DisplayName
+		}, nil
+	case "epic":
+		return epicLane(ctx, client), nil
+	}
+	return nil, fmt.Errorf("invalid --swimlanes %q: use assignee, epic or none", lanes)
+}
+
+// epicLane names an issue's epic: its parent on Jira Cloud, or the Epic
+// Link field on Server/DC, where only the epic's key is known.
+func epicLane(ctx context.Context, client *api.Client) func(*api.Issue) string {
+	var epicLink string
+	if field, err := client.LookupFieldContext(ctx, "Epic Link"); err == nil {
+		epicLink = field.ID
+	}
+
+	return func(issue *api.Issue) string {
+		if raw, ok := issue.Fields.Other["parent"]; ok {
+			var parent struct {
+				Key    string `json:"key"`
+				Fields struct {
+					Summary   string        `json:"summary"`
+					IssueType api.IssueType `json:"issuetype"`
+				} `json:"fields"`
+			}
+			if json.Unmarshal(raw, &parent) == nil && strings.EqualFold(parent.Fields.IssueType.Name, "Epic") {
+				return parent.Key + " " + parent.Fields.Summary
+			}
+		}
+		if raw, ok := issue.Fields.Other[epicLink]; ok && epicLink != "" {
+			var key string
+			if json.Unmarshal(raw, &key) == nil && key != "" {
+				return key
+			}
+		}
+		return "(No epic)"
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(boardCmd)
+	boardCmd.Flags().Int("board", 0, "agile board ID (default from default_board or the default project)")
+	boardCmd.Flags().String("sprint", "current", "sprint to show: current, next, a sprint ID or name")
+	boardCmd.Flags().String("jql", "", "show the results of a JQL query instead of a sprint")
+	boardCmd.Flags().String("swimlanes", "none", "group cards into rows by assignee, epic or none")
+	boardCmd.Flags().IntP("limit", "l", 200, "maximum number of tickets to show (0 for no limit)")
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/git"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// openTicketsJQL finds the tickets offered when no key is given.
+const openTicketsJQL = "assignee = currentUser() AND resolution = Unresolved ORDER BY updated DESC"
+
+var branchCmd = &cobra.Command{
+	Use:   "branch [ticket-key]",
+	Short: "Create and check out a git branch for a ticket",
+	Long: `Create a git branch named after a ticket and check it out. If the branch
+already exists, it's checked out.
+
+The name comes from the branch_template setting, a Go template with
+.Key, .Summary, .Type (the issue type as a slug) and .Project, and the
+functions slug, lower and upper. The default is:
+
+  {{.Type}}/{{.Key}}-{{slug .Summary}}
+
+which names a story PROJ-123 "Add login page" story/PROJ-123-add-login-page.
+
+Without a ticket key, pick one of your open tickets.
+
+Examples:
+  jira branch PROJ-123
+  jira branch PROJ-123 --start       # Also move it to In Progress and assign it to you
+  jira branch PROJ-123 --base main   # Branch off main instead of HEAD
+  jira branch                        # Choose from your open tickets
+  jira branch PROJ-123 --print       # Only print the branch name`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		start, _ := cmd.Flags().GetBool("start")
+		base, _ := cmd.Flags().GetString("base")
+		printOnly, _ := cmd.Flags().GetBool("print")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		var repo *git.Repo
+		if !printOnly {
+			var err error
+			repo, err = git.Open("")
+			ui.FatalIfError(err, "Error opening repository")
+		}
+
+		var issue *api.Issue
+		var err error
+		if len(args) > 0 {
+			ui.Progress("Fetching %s...\n", args[0])
+			issue, err = client.GetIssueContext(ctx, args[0])
+			ui.FatalIfError(err, "Error fetching ticket")
+		} else {
+			issue, err = pickOpenTicket(ctx, client)
+			ui.FatalIfError(err, "Error choosing ticket")
+		}
+
+		name, err := git.BranchName(cfg.BranchTemplate, git.BranchData{
+			Key:     issue.Key,
+			Summary: issue.Fields.Summary,
+			Type:    git.Slug(issue.Fields.IssueType.Name),
+			Project: issue.Fields.Project.Key,
+		})
+		ui.FatalIfError(err, "Invalid branch_template")
+
+		doc := ui.BranchJSON{Key: issue.Key, Branch: name}
+		if printOnly {
+			ui.Result(doc, "%s\n", name)
+			return
+		}
+		ui.FatalIfError(repo.CheckBranchName(name), "Invalid branch name")
+
+		exists, err := repo.BranchExists(name)
+		ui.FatalIfError(err, "Error checking branches")
+		if !exists {
+			ui.FatalIfError(repo.CreateBranch(name, base), "Error creating branch")
+			doc.Created = true
+		}
+		ui.FatalIfError(repo.Checkout(name), "Error checking out branch")
+
+		message := fmt.Sprintf("✅ Switched to existing branch %s\n", name)
+		if doc.Created {
+			message = fmt.Sprintf("✅ Created and switched to branch %s\n", name)
+		}
+
+		if start {
+			ui.Progress("Starting work on %s...\n", issue.Key)
+			doc.Warnings, err = startIssue(ctx, client, issue.Key)
+			ui.FatalIfError(err, "Error updating status")
+			doc.Started = true
+			message += fmt.Sprintf("✅ %s is now In Progress and assigned to you\n", issue.Key)
+		}
+
+		ui.Result(doc, "%s", message)
+	},
+}
+
+// pickOpenTicket asks which of the user's open tickets to use.
+func pickOpenTicket(ctx context.Context, client *api.Client) (*api.Issue, error) {
+	if !stdinIsTerminal() {
+		return nil, errors.New("no ticket key given and no terminal to choose one")
+	}
+
+	ui.Progress("Fetching your open tickets...\n")
+	results, err := client.SearchIssuesContext(ctx, openTicketsJQL, 50)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Issues) == 0 {
+		return nil, errors.New("you have no open tickets")
+	}
+
+	labels := make([]string, len(results.Issues))
+	for i, issue := range results.Issues {
+		labels[i] = fmt.Sprintf("%-10s %-12s %s", issue.Key, ui.Truncate(issue.Fields.Status.Name, 12), ui.Truncate(issue.Fields.Summary, 60))
+	}
+	var chosen int
+	if err := ask(&survey.Select{Message: "Ticket:", Options: labels, PageSize: 15}, &chosen); err != nil {
+		return nil, err
+	}
+	return &results.Issues[chosen], nil
+}
+
+func init() {
+	rootCmd.AddCommand(branchCmd)
+	branchCmd.Flags().Bool("start", false, "also move the ticket to In Progress and assign it to you")
+	branchCmd.Flags().String("base", "", "commit or branch to create the branch from (default HEAD)")
+	branchCmd.Flags().Bool("print", false, "print the branch name without touching the repository")
+}
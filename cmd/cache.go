@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect or clear the local cache",
+	Long: `Tickets, comments, searches and metadata read from Jira are kept in a
+local cache, one directory per Jira site. They're reused for cache_ttl
+(5m by default, metadata for a day) and dropped as soon as the CLI
+changes the ticket. Use --no-cache to always fetch, --offline to never
+fetch, and jira sync to fill the cache ahead of time.`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show what's in the cache",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.LoadAndValidate()
+		c := cfg.OpenCache()
+		if c == nil {
+			ui.FatalError("no cache directory is available")
+		}
+
+		stats, err := c.Stats()
+		ui.FatalIfError(err, "Error reading cache")
+		ui.RenderCacheStats(c, stats)
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the cache",
+	Long: `Delete the cached data of the configured Jira site, or of every site
+with --all.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+
+		dir, err := cache.Root()
+		ui.FatalIfError(err, "Error locating cache")
+		if !all {
+			cfg := config.LoadAndValidate()
+			c := cfg.OpenCache()
+			if c == nil {
+				ui.FatalError("no cache directory is available")
+			}
+			dir = c.Dir
+		}
+
+		err = os.RemoveAll(dir)
+		ui.FatalIfError(err, "Error clearing cache")
+		ui.Result(map[string]string{"cleared": dir}, "Cleared %s\n", dir)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheClearCmd.Flags().Bool("all", false, "clear the cache of every Jira site")
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/danielyan21/JiraCLI/internal/config"
 	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
@@ -11,19 +9,21 @@ import (
 var commentCmd = &cobra.Command{
 	Use:   "comment [ticket-key] [comment-text]",
 	Short: "Add a comment to a Jira ticket",
-	Args:  cobra.ExactArgs(2),
+	Args:  ticketKeyArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		ticketKey := args[0]
-		commentText := args[1]
-
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
 		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 2, false)
+		ticketKey := args[0]
+		commentText := args[1]
 
-		fmt.Printf("Adding comment to %s...\n", ticketKey)
-		err := client.AddComment(ticketKey, commentText)
+		ui.Progress("Adding comment to %s...\n", ticketKey)
+		err := client.AddCommentContext(ctx, ticketKey, commentText)
 		ui.FatalIfError(err, "Error adding comment")
 
-		fmt.Printf("✅ Comment added successfully to %s\n", ticketKey)
+		ui.Result(ui.ActionJSON{Key: ticketKey, Action: "comment", Comment: commentText},
+			"✅ Comment added successfully to %s\n", ticketKey)
 	},
 }
 
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/git"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var commitsCmd = &cobra.Command{
+	Use:   "commits [ticket-key]",
+	Short: "List the local git commits that mention a ticket",
+	Long: `List the commits in the local repository whose message mentions a
+ticket key, newest first. Only commits reachable from HEAD are searched
+unless --all is given.
+
+Examples:
+  jira commits PROJ-123
+  jira commits PROJ-123 --all     # Search every branch
+  jira commits                    # The ticket named by the current git branch`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		all, _ := cmd.Flags().GetBool("all")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		repo, err := git.Open("")
+		ui.FatalIfError(err, "Error opening repository")
+
+		// Only a key taken from the branch needs the configured profile.
+		if len(args) == 0 {
+			cfg := config.LoadAndValidate()
+			args = prependBranchTicket(ctx, cfg, cfg.NewAPIClient(), args)
+		}
+		ticketKey := strings.ToUpper(args[0])
+
+		commits, err := repo.CommitsMentioning(ticketKey, all, limit)
+		ui.FatalIfError(err, "Error reading commits")
+
+		doc := ui.CommitListJSON{Key: ticketKey, Commits: make([]ui.CommitJSON, 0, len(commits))}
+		for _, commit := range commits {
+			doc.Commits = append(doc.Commits, ui.CommitJSON{
+				SHA:     commit.SHA,
+				Author:  commit.Author,
+				Date:    commit.Date,
+				Subject: commit.Subject,
+			})
+		}
+		ui.RenderCommits(&doc)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(commitsCmd)
+	commitsCmd.Flags().Bool("all", false, "search the commits of every branch, not just HEAD")
+	commitsCmd.Flags().IntP("limit", "l", 50, "maximum number of commits (0 for no limit)")
+}
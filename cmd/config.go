@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage configuration profiles",
+	Long: `Manage configuration profiles for multiple Jira instances.
+
+Each profile holds its own URL, credentials and default project. The active
+profile is chosen by --profile, then JIRA_PROFILE, then the default set with
+'jira config use'.
+
+Examples:
+  jira init --profile onprem    # Create a profile
+  jira config list              # Show all profiles
+  jira config use onprem        # Make "onprem" the default
+  jira config remove onprem     # Delete a profile`,
+}
+
+type profileJSON struct {
+	Name           string `json:"name"`
+	JiraURL        string `json:"jiraUrl"`
+	Email          string `json:"email,omitempty"`
+	AuthType       string `json:"authType"`
+	DefaultProject string `json:"defaultProject,omitempty"`
+	Default        bool   `json:"default"`
+	Active         bool   `json:"active"`
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configuration profiles",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		profiles, err := config.ListProfiles()
+		ui.FatalIfError(err, "Error reading profiles")
+
+		active := config.ActiveProfile()
+
+		if ui.JSONOutput() {
+			docs := make([]profileJSON, 0, len(profiles))
+			for _, p := range profiles {
+				authType := p.Config.AuthType
+				if authType == "" {
+					authType = "basic"
+				}
+				docs = append(docs, profileJSON{
+					Name:           p.Name,
+					JiraURL:        p.Config.JiraURL,
+					Email:          p.Config.Email,
+					AuthType:       authType,
+					DefaultProject: p.Config.DefaultProject,
+					Default:        p.Default,
+					Active:         p.Name == active,
+				})
+			}
+			ui.PrintJSON(docs)
+			return
+		}
+
+		if len(profiles) == 0 {
+			fmt.Println("No profiles configured. Run 'jira init' to create one.")
+			return
+		}
+
+		c := ui.NewColorFuncs()
+		for _, p := range profiles {
+			marker := "  "
+			if p.Name == active {
+				marker = c.Green("* ")
+			}
+			name := fmt.Sprintf("%-12s", p.Name)
+			line := fmt.Sprintf("%s%s %s", marker, c.Cyan(name), p.Config.JiraURL)
+			if p.Config.DefaultProject != "" {
+				line += c.Gray(fmt.Sprintf(" (project %s)", p.Config.DefaultProject))
+			}
+			if p.Default {
+				line += c.Gray(" [default]")
+			}
+			fmt.Println(line)
+		}
+	},
+}
+
+var configUseCmd = &cobra.Command{
+	Use:   "use [profile]",
+	Short: "Set the default profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		err := config.UseProfile(name)
+		ui.FatalIfError(err, "Error switching profile")
+
+		ui.Result(map[string]string{"profile": name, "action": "use"},
+			"✅ Now using profile '%s'\n", name)
+	},
+}
+
+var configRemoveCmd = &cobra.Command{
+	Use:     "remove [profile]",
+	Aliases: []string{"rm"},
+	Short:   "Delete a profile",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		err := config.RemoveProfile(name)
+		ui.FatalIfError(err, "Error removing profile")
+
+		ui.Result(map[string]string{"profile": name, "action": "remove"},
+			"✅ Removed profile '%s'\n", name)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configUseCmd)
+	configCmd.AddCommand(configRemoveCmd)
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
+	"github.com/danielyan21/JiraCLI/internal/api"
 	"github.com/danielyan21/JiraCLI/internal/config"
 	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
@@ -12,69 +16,147 @@ import (
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new Jira issue",
-	Long: `Create a new Jira issue interactively.
+	Long: `Create a new Jira issue.
 
-The command will prompt you for all required information with
-arrow key navigation and dropdown selections.`,
+Values can be given as flags. Issue types, priorities and required
+fields are read from the project's create screen in Jira. When stdin is
+a terminal, any required value that is missing is prompted for, with a
+list to pick from where Jira defines the allowed values; run with no
+flags at all for the full interactive form. Without a terminal, missing
+required values are an error, so the command is safe to use in scripts.
+
+--field takes a field's display name or ID. Values are converted to the
+field's type: numbers, dates (YYYY-MM-DD), users (@me, account ID or
+username), select options by name, and comma-separated lists for
+multi-value fields.
+
+Examples:
+  jira create                                      # Interactive form
+  jira create -p PROJ -s "Login broken" -t Bug --priority High
+  jira create -s "Update docs" --label docs --label onboarding
+  jira create -s "Subtask" -t Sub-task --parent PROJ-123
+  git log -1 --format=%B | jira create -s "Release notes" --description-file -
+  jira create -s "Spike" --field "Story Points=3" --field Team=Platform
+  jira create -s "Spike" --field 'customfield_10020=[{"id":"1"}]'`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
 		client := cfg.NewAPIClient()
 
-		var project string
-		projectPrompt := &survey.Input{
-			Message: "Project:",
-			Default: cfg.DefaultProject,
-		}
-		survey.AskOne(projectPrompt, &project, survey.WithValidator(survey.Required))
+		opts, err := createOptionsFromFlags(ctx, cmd, client, cfg)
+		ui.FatalIfError(err, "Invalid flags")
 
-		var summary string
-		summaryPrompt := &survey.Input{
-			Message: "Summary:",
-		}
-		survey.AskOne(summaryPrompt, &summary, survey.WithValidator(survey.Required))
+		err = completeCreateOptions(ctx, cmd, client, &opts)
+		ui.FatalIfError(err, "Error reading issue details")
 
-		var issueType string
-		issueTypePrompt := &survey.Select{
-			Message: "Issue Type:",
-			Options: []string{"Task", "Bug", "Story", "Epic", "Subtask"},
-			Default: "Task",
-		}
-		survey.AskOne(issueTypePrompt, &issueType)
+		ui.Progress("\nCreating issue in %s...\n", opts.Project)
+		result, err := client.CreateIssueWithOptionsContext(ctx, opts)
+		ui.FatalIfError(err, "Error creating issue")
+
+		issueURL := fmt.Sprintf("%s/browse/%s", cfg.JiraURL, result.Key)
+		ui.Result(ui.CreatedJSON{ID: result.ID, Key: result.Key, URL: issueURL},
+			"\n✅ Issue created successfully!\n   Key: %s\n   URL: %s\n", result.Key, issueURL)
+	},
+}
+
+// createOptionsFromFlags collects everything given on the command line.
+func createOptionsFromFlags(ctx context.Context, cmd *cobra.Command, client *api.Client, cfg *config.Config) (api.CreateIssueOptions, error) {
+	flags := cmd.Flags()
+	opts := api.CreateIssueOptions{Project: cfg.DefaultProject}
 
-		var priority string
-		priorityPrompt := &survey.Select{
-			Message: "Priority:",
-			Options: []string{"None", "Highest", "High", "Medium", "Low", "Lowest"},
-			Default: "None",
+	if project, _ := flags.GetString("project"); project != "" {
+		opts.Project = project
+	}
+	opts.Summary, _ = flags.GetString("summary")
+	opts.IssueType, _ = flags.GetString("type")
+	opts.Priority, _ = flags.GetString("priority")
+	opts.Assignee, _ = flags.GetString("assignee")
+	opts.Labels, _ = flags.GetStringArray("label")
+	opts.Components, _ = flags.GetStringArray("component")
+	opts.Parent, _ = flags.GetString("parent")
+	opts.Description, _ = flags.GetString("description")
+
+	if path, _ := flags.GetString("description-file"); path != "" {
+		if flags.Changed("description") {
+			return opts, fmt.Errorf("--description and --description-file can't be used together")
 		}
-		survey.AskOne(priorityPrompt, &priority)
-		if priority == "None" {
-			priority = ""
+		description, err := readDescriptionFile(path)
+		if err != nil {
+			return opts, err
 		}
+		opts.Description = description
+	}
 
-		var description string
-		descriptionPrompt := &survey.Multiline{
-			Message: "Description (optional):",
+	assignToMe, _ := flags.GetBool("assign-to-me")
+	if assignToMe {
+		if opts.Assignee != "" {
+			return opts, fmt.Errorf("--assignee and --assign-to-me can't be used together")
 		}
-		survey.AskOne(descriptionPrompt, &description)
+		opts.Assignee = "me"
+	}
 
-		var assignToMe bool
-		assignPrompt := &survey.Confirm{
-			Message: "Assign to yourself?",
-			Default: false,
-		}
-		survey.AskOne(assignPrompt, &assignToMe)
+	rawFields, _ := flags.GetStringArray("field")
+	fields, err := parseFieldFlags(rawFields)
+	if err != nil {
+		return opts, err
+	}
+	opts.Fields, err = client.ResolveFieldsContext(ctx, fields)
+	if err != nil {
+		return opts, err
+	}
 
-		fmt.Printf("\nCreating issue in %s...\n", project)
-		result, err := client.CreateIssue(project, summary, description, issueType, priority, assignToMe)
-		ui.FatalIfError(err, "Error creating issue")
+	return opts, nil
+}
 
-		fmt.Printf("\n✅ Issue created successfully!\n")
-		fmt.Printf("   Key: %s\n", result.Key)
-		fmt.Printf("   URL: %s/browse/%s\n", cfg.JiraURL, result.Key)
-	},
+// readDescriptionFile reads a description from a file, or from stdin when
+// path is "-".
+func readDescriptionFile(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading description: %w", err)
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// parseFieldFlags parses repeated --field name=value flags. Names may be
+// field IDs or display names; values are coerced by api.ResolveFields.
+func parseFieldFlags(raw []string) (map[string]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	fields := make(map[string]string, len(raw))
+	for _, entry := range raw {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --field %q: expected name=value", entry)
+		}
+		fields[key] = value
+	}
+	return fields, nil
 }
 
 func init() {
 	rootCmd.AddCommand(createCmd)
+
+	createCmd.Flags().StringP("project", "p", "", "Project key (defaults to the configured default project)")
+	createCmd.Flags().StringP("summary", "s", "", "Issue summary")
+	createCmd.Flags().StringP("type", "t", "", "Issue type (defaults to Task when the project has it)")
+	createCmd.Flags().String("priority", "", "Priority name (e.g. High)")
+	createCmd.Flags().StringP("description", "d", "", "Description in Markdown")
+	createCmd.Flags().String("description-file", "", "Read the description from a file, or '-' for stdin")
+	createCmd.Flags().StringP("assignee", "a", "", "Assignee: @me, an account ID (Cloud) or a username (Server/DC)")
+	createCmd.Flags().Bool("assign-to-me", false, "Assign the issue to yourself")
+	createCmd.Flags().StringArrayP("label", "l", nil, "Add a label (repeatable)")
+	createCmd.Flags().StringArray("component", nil, "Add a component by name (repeatable)")
+	createCmd.Flags().String("parent", "", "Parent issue key, for sub-tasks and child issues")
+	createCmd.Flags().StringArray("field", nil, "Set any field as name=value, by display name or ID; JSON objects/arrays are sent as-is except to text fields (repeatable)")
 }
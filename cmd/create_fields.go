@@ -0,0 +1,453 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+const defaultIssueType = "Task"
+
+// createSession holds what completeCreateOptions needs while it walks the
+// create screen: whether it may prompt, and whether to run the full form.
+type createSession struct {
+	ctx         context.Context
+	client      *api.Client
+	opts        *api.CreateIssueOptions
+	terminal    bool
+	interactive bool
+}
+
+// completeCreateOptions validates the flags against the project's create
+// metadata and fills in whatever is missing. With no create flags at all
+// it runs the full interactive form; otherwise it only asks for required
+// values. It never prompts when stdin isn't a terminal. If the metadata
+// can't be loaded it falls back to sending what it has and letting Jira
+// validate.
+func completeCreateOptions(ctx context.Context, cmd *cobra.Command, client *api.Client, opts *api.CreateIssueOptions) error {
+	fromStdin, _ := cmd.Flags().GetString("description-file")
+	s := &createSession{
+		ctx:      ctx,
+		client:   client,
+		opts:     opts,
+		terminal: stdinIsTerminal() && fromStdin != "-",
+	}
+	s.interactive = s.terminal && cmd.LocalFlags().NFlag() == 0
+
+	if !s.terminal {
+		var missing []string
+		if opts.Project == "" {
+			missing = append(missing, "--project")
+		}
+		if opts.Summary == "" {
+			missing = append(missing, "--summary")
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing required %s (stdin is not a terminal, so it can't be prompted for)",
+				strings.Join(missing, ", "))
+		}
+	}
+
+	if opts.Project == "" || s.interactive {
+		err := ask(&survey.Input{Message: "Project:", Default: opts.Project},
+			&opts.Project, survey.WithValidator(survey.Required))
+		if err != nil {
+			return err
+		}
+	}
+
+	issueType, err := s.chooseIssueType()
+	if err != nil {
+		return err
+	}
+
+	if opts.Summary == "" {
+		err := ask(&survey.Input{Message: "Summary:"}, &opts.Summary, survey.WithValidator(survey.Required))
+		if err != nil {
+			return err
+		}
+	}
+
+	var fields []api.CreateMetaField
+	if issueType != nil {
+		fields, err = client.GetCreateFieldsContext(ctx, opts.Project, issueType.ID)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			ui.Progress("Warning: Could not load the create screen for %s: %v\n", issueType.Name, err)
+		}
+	}
+
+	if err := s.choosePriority(fields); err != nil {
+		return err
+	}
+
+	if err := s.fillRequiredFields(fields); err != nil {
+		return err
+	}
+
+	if !s.interactive {
+		return nil
+	}
+
+	if opts.Description == "" {
+		err := ask(&survey.Multiline{Message: "Description (optional):"}, &opts.Description)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Assignee == "" {
+		var assignToMe bool
+		err := ask(&survey.Confirm{Message: "Assign to yourself?", Default: false}, &assignToMe)
+		if err != nil {
+			return err
+		}
+		if assignToMe {
+			opts.Assignee = "me"
+		}
+	}
+	return nil
+}
+
+// chooseIssueType resolves --type against the project's issue types, or
+// offers them as a list in the interactive form. It returns nil when the
+// issue types couldn't be loaded.
+func (s *createSession) chooseIssueType() (*api.IssueType, error) {
+	issueTypes, err := s.client.GetCreateIssueTypesContext(s.ctx, s.opts.Project)
+	if err != nil || len(issueTypes) == 0 {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		if err != nil {
+			ui.Progress("Warning: Could not load issue types for %s: %v\n", s.opts.Project, err)
+		}
+		if s.opts.IssueType == "" {
+			s.opts.IssueType = defaultIssueType
+		}
+		return nil, nil
+	}
+
+	var names []string
+	for _, issueType := range issueTypes {
+		names = append(names, issueType.Name)
+	}
+
+	if s.opts.IssueType == "" || s.interactive {
+		name := s.opts.IssueType
+		if name == "" {
+			name = defaultIssueType
+		}
+		if match := findIssueType(issueTypes, name); match != nil {
+			name = match.Name
+		} else {
+			name = names[0]
+		}
+
+		if !s.terminal {
+			s.opts.IssueType = name
+		} else if err := ask(&survey.Select{Message: "Issue Type:", Options: names, Default: name}, &s.opts.IssueType); err != nil {
+			return nil, err
+		}
+	}
+
+	match := findIssueType(issueTypes, s.opts.IssueType)
+	if match == nil {
+		return nil, fmt.Errorf("issue type %q isn't available in %s (available: %s)",
+			s.opts.IssueType, s.opts.Project, strings.Join(names, ", "))
+	}
+	s.opts.IssueType = match.Name
+	return match, nil
+}
+
+func findIssueType(issueTypes []api.IssueType, name string) *api.IssueType {
+	for i := range issueTypes {
+		if strings.EqualFold(issueTypes[i].Name, name) || issueTypes[i].ID == name {
+			return &issueTypes[i]
+		}
+	}
+	return nil
+}
+
+// choosePriority checks --priority against the allowed priorities, or
+// offers them as a list in the interactive form. Issue types without a
+// priority field on their create screen are left alone.
+func (s *createSession) choosePriority(fields []api.CreateMetaField) error {
+	field := findCreateField(fields, "priority")
+	if field == nil {
+		return nil
+	}
+
+	allowed := field.AllowedValues
+	if len(allowed) == 0 && (s.interactive || s.opts.Priority != "") {
+		priorities, err := s.client.GetPrioritiesContext(s.ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			ui.Progress("Warning: Could not load priorities: %v\n", err)
+		}
+		for _, priority := range priorities {
+			allowed = append(allowed, api.AllowedValue{ID: priority.ID, Name: priority.Name})
+		}
+	}
+
+	if s.opts.Priority != "" {
+		if len(allowed) == 0 {
+			return nil
+		}
+		match := findAllowedValue(allowed, s.opts.Priority)
+		if match == nil {
+			return fmt.Errorf("priority %q isn't allowed (available: %s)", s.opts.Priority, allowedLabels(allowed))
+		}
+		s.opts.Priority = match.Label()
+		return nil
+	}
+
+	if !s.interactive && !(field.Required && !field.HasDefaultValue && s.terminal) {
+		return nil
+	}
+	if len(allowed) == 0 {
+		return ask(&survey.Input{Message: "Priority:"}, &s.opts.Priority)
+	}
+
+	var options []string
+	if !field.Required {
+		options = append(options, "None")
+	}
+	for _, value := range allowed {
+		options = append(options, value.Label())
+	}
+	if err := ask(&survey.Select{Message: "Priority:", Options: options}, &s.opts.Priority); err != nil {
+		return err
+	}
+	if s.opts.Priority == "None" && !field.Required {
+		s.opts.Priority = ""
+	}
+	return nil
+}
+
+// fillRequiredFields prompts for required fields that have no value and no
+// default. Without a terminal it reports them all at once instead.
+func (s *createSession) fillRequiredFields(fields []api.CreateMetaField) error {
+	var missing []string
+	for _, field := range fields {
+		if !field.Required || field.HasDefaultValue || s.hasValue(field.ID()) {
+			continue
+		}
+		if !s.terminal {
+			missing = append(missing, fmt.Sprintf("%s (%s)", field.Name, field.ID()))
+			continue
+		}
+		if err := s.promptField(field); err != nil {
+			return err
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%s in %s requires: %s; set them with --field or the matching flags",
+			s.opts.IssueType, s.opts.Project, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// hasValue reports whether the field is already set by a flag.
+func (s *createSession) hasValue(id string) bool {
+	switch id {
+	case "project", "summary", "issuetype":
+		return true
+	case "priority":
+		return s.opts.Priority != ""
+	case "description":
+		return s.opts.Description != ""
+	case "assignee":
+		return s.opts.Assignee != ""
+	case "labels":
+		return len(s.opts.Labels) > 0
+	case "components":
+		return len(s.opts.Components) > 0
+	case "parent":
+		return s.opts.Parent != ""
+	}
+	_, ok := s.opts.Fields[id]
+	return ok
+}
+
+// promptField asks for a required field, choosing the prompt from its
+// allowed values or schema type.
+func (s *createSession) promptField(field api.CreateMetaField) error {
+	message := field.Name + ":"
+	required := survey.WithValidator(survey.Required)
+
+	switch field.ID() {
+	case "description":
+		return ask(&survey.Multiline{Message: message}, &s.opts.Description, required)
+	case "assignee":
+		return ask(&survey.Input{Message: message, Help: "@me, an account ID or a username"}, &s.opts.Assignee, required)
+	case "parent":
+		return ask(&survey.Input{Message: message, Help: "Parent issue key"}, &s.opts.Parent, required)
+	case "labels":
+		var labels string
+		if err := ask(&survey.Input{Message: message, Help: "Comma-separated"}, &labels, required); err != nil {
+			return err
+		}
+		s.opts.Labels = splitList(labels)
+		return nil
+	case "components":
+		if len(field.AllowedValues) == 0 {
+			var components string
+			if err := ask(&survey.Input{Message: message, Help: "Comma-separated"}, &components, required); err != nil {
+				return err
+			}
+			s.opts.Components = splitList(components)
+			return nil
+		}
+	}
+
+	var value interface{}
+	var err error
+	if len(field.AllowedValues) > 0 {
+		value, err = promptAllowedValue(field, message)
+	} else {
+		value, err = s.promptSchemaValue(field, message)
+	}
+	if err != nil {
+		return err
+	}
+
+	if field.ID() == "components" {
+		components, ok := value.([]interface{})
+		if !ok {
+			components = []interface{}{value}
+		}
+		for _, component := range components {
+			s.opts.Components = append(s.opts.Components, findAllowedValueByID(field.AllowedValues, component))
+		}
+		return nil
+	}
+
+	if s.opts.Fields == nil {
+		s.opts.Fields = map[string]interface{}{}
+	}
+	s.opts.Fields[field.ID()] = value
+	return nil
+}
+
+// promptAllowedValue offers a field's allowed values as a select, or a
+// multi-select for array fields, and returns them as {"id": ...} refs.
+func promptAllowedValue(field api.CreateMetaField, message string) (interface{}, error) {
+	labels := make([]string, len(field.AllowedValues))
+	for i, value := range field.AllowedValues {
+		labels[i] = value.Label()
+	}
+
+	if field.Schema.Type == "array" {
+		var chosen []int
+		err := ask(&survey.MultiSelect{Message: message, Options: labels}, &chosen,
+			survey.WithValidator(survey.MinItems(1)))
+		if err != nil {
+			return nil, err
+		}
+		refs := make([]interface{}, len(chosen))
+		for i, index := range chosen {
+			refs[i] = map[string]interface{}{"id": field.AllowedValues[index].ID}
+		}
+		return refs, nil
+	}
+
+	var chosen int
+	if err := ask(&survey.Select{Message: message, Options: labels}, &chosen); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"id": field.AllowedValues[chosen].ID}, nil
+}
+
+// promptSchemaValue asks for a free-form value and converts it to the shape
+// the field's schema expects.
+func (s *createSession) promptSchemaValue(field api.CreateMetaField, message string) (interface{}, error) {
+	var input string
+	var help string
+	validators := []survey.Validator{survey.Required}
+
+	switch field.Schema.Type {
+	case "number":
+		help = "A number"
+		validators = append(validators, func(ans interface{}) error {
+			_, err := strconv.ParseFloat(strings.TrimSpace(ans.(string)), 64)
+			return err
+		})
+	case "date":
+		help = "YYYY-MM-DD"
+		validators = append(validators, func(ans interface{}) error {
+			_, err := time.Parse("2006-01-02", strings.TrimSpace(ans.(string)))
+			return err
+		})
+	case "user":
+		help = "@me, an account ID (Cloud) or a username (Server/DC)"
+	case "array":
+		help = "Comma-separated"
+	}
+
+	err := ask(&survey.Input{Message: message, Help: help}, &input, survey.WithValidator(survey.ComposeValidators(validators...)))
+	if err != nil {
+		return nil, err
+	}
+	return s.client.FieldValueContext(s.ctx, field.Schema, input)
+}
+
+func findCreateField(fields []api.CreateMetaField, id string) *api.CreateMetaField {
+	for i := range fields {
+		if fields[i].ID() == id {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func findAllowedValue(values []api.AllowedValue, label string) *api.AllowedValue {
+	for i := range values {
+		if strings.EqualFold(values[i].Label(), label) || values[i].ID == label {
+			return &values[i]
+		}
+	}
+	return nil
+}
+
+// findAllowedValueByID maps an {"id": ...} ref back to the value's name.
+func findAllowedValueByID(values []api.AllowedValue, ref interface{}) string {
+	m, _ := ref.(map[string]interface{})
+	id, _ := m["id"].(string)
+	for _, value := range values {
+		if value.ID == id {
+			return value.Label()
+		}
+	}
+	return id
+}
+
+func allowedLabels(values []api.AllowedValue) string {
+	labels := make([]string, len(values))
+	for i, value := range values {
+		labels[i] = value.Label()
+	}
+	return strings.Join(labels, ", ")
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
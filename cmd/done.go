@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/danielyan21/JiraCLI/internal/api"
 	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/timer"
 	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -13,25 +16,47 @@ var doneCmd = &cobra.Command{
 	Short: "Mark a ticket as done",
 	Long: `Mark a ticket as "Done".
 
-This is a quick action that updates the ticket status to "Done".
+This is a quick action that updates the ticket status to "Done". If a
+timer started with "jira start --timer" is running for the ticket, its
+time is logged first.
 
 Examples:
-  jira done PROJ-123`,
-	Args: cobra.ExactArgs(1),
+  jira done PROJ-123
+  jira done PROJ-123 -m "Shipped in 2.4"   # Comment for the timer's worklog`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ticketKey := args[0]
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
 		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 1, false)
+		ticketKey := args[0]
 
-		fmt.Printf("Marking %s as done...\n", ticketKey)
-
-		err := client.UpdateIssueStatus(ticketKey, "Done")
+		doc := ui.ActionJSON{Key: ticketKey, Action: "done", Status: "Done"}
+		var logged string
+
+		running, err := timer.Load()
+		ui.FatalIfError(err, "Error reading timer")
+		if running != nil && strings.EqualFold(running.Key, ticketKey) {
+			comment, _ := cmd.Flags().GetString("comment")
+			worklog, err := logTimer(ctx, client, cfg, running, comment, api.EstimateAdjustment{})
+			ui.FatalIfError(err, "Error logging timer")
+			if worklog != nil {
+				worklogDoc := ui.NewWorklogJSON(worklog)
+				doc.Worklog = &worklogDoc
+				logged = fmt.Sprintf(" (logged %s)", ui.FormatDuration(worklog.TimeSpentSeconds))
+			}
+		}
+
+		ui.Progress("Marking %s as done...\n", ticketKey)
+
+		err = client.UpdateIssueStatusContext(ctx, ticketKey, "Done")
 		ui.FatalIfError(err, "Error updating status")
 
-		fmt.Printf("✅ %s is now Done\n", ticketKey)
+		ui.Result(doc, "✅ %s is now Done%s\n", ticketKey, logged)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(doneCmd)
+	doneCmd.Flags().StringP("comment", "m", "", "Comment for the worklog of a running timer")
 }
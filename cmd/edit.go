@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/danielyan21/JiraCLI/internal/adf"
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit [ticket-key]",
+	Short: "Edit the fields of a ticket",
+	Long: `Edit the fields of a Jira ticket.
+
+Change fields with flags, or run without flags (or with --editor) to open
+the summary and description in $EDITOR. The first line of the file is the
+summary and everything after the blank line below it is the description,
+in Markdown. Only what you changed is sent back to Jira.
+
+Multi-valued fields (labels, components, fix versions and custom
+multi-selects) are changed with add/remove operations, so values you
+don't mention are kept.
+
+Examples:
+  jira edit PROJ-123                                # Edit summary and description in $EDITOR
+  jira edit PROJ-123 -s "Fix login on Safari" --priority High
+  jira edit PROJ-123 --add-label backend --remove-label triage
+  jira edit PROJ-123 --add-fix-version 2.4 --due 2025-07-01
+  jira edit PROJ-123 --field "Story Points=5" --add "Team Members=@me"
+  jira edit PROJ-123 --due ""                       # Clear the due date`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 1, false)
+		ticketKey := args[0]
+
+		update, err := issueUpdateFromFlags(ctx, cmd, client)
+		ui.FatalIfError(err, "Invalid flags")
+
+		useEditor, _ := cmd.Flags().GetBool("editor")
+		if update.IsEmpty() && !useEditor {
+			if !stdinIsTerminal() {
+				ui.FatalError("nothing to change: pass field flags, or run in a terminal to use $EDITOR")
+			}
+			useEditor = true
+		}
+
+		if useEditor {
+			issue, err := client.GetFreshIssueContext(ctx, ticketKey)
+			ui.FatalIfError(err, "Error fetching ticket")
+
+			err = editInEditor(issue, client, update)
+			ui.FatalIfError(err, "Error editing ticket")
+		}
+
+		if update.IsEmpty() {
+			ui.Result(ui.ActionJSON{Key: ticketKey, Action: "edit"}, "No changes to %s\n", ticketKey)
+			return
+		}
+
+		ui.Progress("Updating %s...\n", ticketKey)
+		err = client.EditIssueContext(ctx, ticketKey, update)
+		ui.FatalIfError(err, "Error updating ticket")
+
+		changed := changedFields(update)
+		ui.Result(ui.ActionJSON{Key: ticketKey, Action: "edit", Fields: changed},
+			"Successfully updated %s (%s)\n", ticketKey, strings.Join(changed, ", "))
+	},
+}
+
+// issueUpdateFromFlags builds the update from the field flags.
+func issueUpdateFromFlags(ctx context.Context, cmd *cobra.Command, client *api.Client) (*api.IssueUpdate, error) {
+	flags := cmd.Flags()
+	update := &api.IssueUpdate{}
+
+	if flags.Changed("summary") {
+		summary, _ := flags.GetString("summary")
+		if strings.TrimSpace(summary) == "" {
+			return nil, errors.New("the summary can't be empty")
+		}
+		update.Set("summary", summary)
+	}
+
+	description, _ := flags.GetString("description")
+	if path, _ := flags.GetString("description-file"); path != "" {
+		if flags.Changed("description") {
+			return nil, errors.New("--description and --description-file can't be used together")
+		}
+		var err error
+		if description, err = readDescriptionFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if flags.Changed("description") || flags.Changed("description-file") {
+		if description == "" {
+			update.Set("description", nil)
+		} else {
+			update.Set("description", client.RichText(description))
+		}
+	}
+
+	if flags.Changed("priority") {
+		priority, _ := flags.GetString("priority")
+		update.Set("priority", map[string]interface{}{"name": priority})
+	}
+
+	if flags.Changed("due") {
+		due, _ := flags.GetString("due")
+		if due == "" {
+			update.Set("duedate", nil)
+		} else {
+			value, err := client.FieldValueContext(ctx, api.FieldSchema{Type: "date"}, due)
+			if err != nil {
+				return nil, fmt.Errorf("--due: %w", err)
+			}
+			update.Set("duedate", value)
+		}
+	}
+
+	rawFields, _ := flags.GetStringArray("field")
+	fields, err := parseFieldFlags(rawFields)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := client.ResolveFieldsContext(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+	for id, value := range resolved {
+		update.Set(id, value)
+	}
+
+	// Shorthands for the common multi-valued system fields.
+	for _, op := range []struct {
+		flag, field, verb string
+		value             func(string) interface{}
+	}{
+		{"add-label", "labels", "add", func(v string) interface{} { return v }},
+		{"remove-label", "labels", "remove", func(v string) interface{} { return v }},
+		{"add-component", "components", "add", nameRef},
+		{"remove-component", "components", "remove", nameRef},
+		{"add-fix-version", "fixVersions", "add", nameRef},
+		{"remove-fix-version", "fixVersions", "remove", nameRef},
+	} {
+		values, _ := flags.GetStringArray(op.flag)
+		for _, value := range values {
+			addUpdateOperation(update, op.field, op.verb, op.value(value))
+		}
+	}
+
+	for _, verb := range []string{"add", "remove"} {
+		entries, _ := flags.GetStringArray(verb)
+		for _, entry := range entries {
+			id, value, err := resolveFieldItem(ctx, client, verb, entry)
+			if err != nil {
+				return nil, err
+			}
+			addUpdateOperation(update, id, verb, value)
+		}
+	}
+
+	return update, nil
+}
+
+func nameRef(name string) interface{} {
+	return map[string]interface{}{"name": name}
+}
+
+func addUpdateOperation(update *api.IssueUpdate, field, verb string, value interface{}) {
+	if verb == "add" {
+		update.Add(field, value)
+	} else {
+		update.Remove(field, value)
+	}
+}
+
+// resolveFieldItem parses an --add/--remove name=value flag into a field ID
+// and a single item of that multi-valued field.
+func resolveFieldItem(ctx context.Context, client *api.Client, verb, entry string) (string, interface{}, error) {
+	name, value, ok := strings.Cut(entry, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return "", nil, fmt.Errorf("invalid --%s %q: expected name=value", verb, entry)
+	}
+
+	field, err := client.LookupFieldContext(ctx, name)
+	if err != nil {
+		return "", nil, err
+	}
+	if field.Schema.Type != "array" {
+		return "", nil, fmt.Errorf("--%s: %s holds a single value; use --field to set it", verb, field.Name)
+	}
+
+	item, err := client.FieldValueContext(ctx, api.FieldSchema{Type: field.Schema.Items}, value)
+	if err != nil {
+		return "", nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	return field.ID, item, nil
+}
+
+func changedFields(update *api.IssueUpdate) []string {
+	var fields []string
+	for id := range update.Fields {
+		fields = append(fields, id)
+	}
+	for id := range update.Update {
+		if _, ok := update.Fields[id]; !ok {
+			fields = append(fields, id)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// editInEditor opens the summary and description in the user's editor and
+// adds whichever of them changed to update.
+func editInEditor(issue *api.Issue, client *api.Client, update *api.IssueUpdate) error {
+	doc, err := adf.FromBody(issue.Fields.Description)
+	if err != nil {
+		return fmt.Errorf("reading description: %w", err)
+	}
+	summary := issue.Fields.Summary
+	description := adf.ToMarkdown(doc)
+
+	if s, ok := update.Fields["summary"].(string); ok {
+		summary = s
+	}
+
+	file, err := os.CreateTemp("", "jira-"+issue.Key+"-*.md")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	_, err = file.WriteString(summary + "\n\n" + description + "\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+
+	if err := runEditor(path); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading edited file: %w", err)
+	}
+	newSummary, newDescription := splitEditedIssue(string(data))
+	if newSummary == "" {
+		return errors.New("aborting: the summary is empty")
+	}
+
+	if newSummary != issue.Fields.Summary {
+		update.Set("summary", newSummary)
+	}
+	if newDescription != strings.TrimSpace(description) {
+		if err := confirmLossyDescription(doc); err != nil {
+			return err
+		}
+		if newDescription == "" {
+			update.Set("description", nil)
+		} else {
+			update.Set("description", client.RichText(newDescription))
+		}
+	}
+	return nil
+}
+
+// confirmLossyDescription asks before replacing a description holding
+// content the Markdown in the editor couldn't show, such as images or
+// panels, since saving would turn it into plain text.
+func confirmLossyDescription(doc *adf.Node) error {
+	losses := adf.MarkdownLosses(doc)
+	if len(losses) == 0 {
+		return nil
+	}
+	warning := fmt.Sprintf("the description has %s, which saving the edited text would replace with plain text", strings.Join(losses, ", "))
+	if !stdinIsTerminal() {
+		return fmt.Errorf("aborting: %s; edit it in Jira instead", warning)
+	}
+
+	ui.Progress("Warning: %s.\n", strings.ToUpper(warning[:1])+warning[1:])
+	save := false
+	if err := ask(&survey.Confirm{Message: "Save the description anyway?"}, &save); err != nil {
+		return err
+	}
+	if !save {
+		return errors.New("aborting: the description was not saved")
+	}
+	return nil
+}
+
+// splitEditedIssue splits the editor file into the summary (first line) and
+// the description (everything after it).
+func splitEditedIssue(content string) (summary, description string) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	summary, description, _ = strings.Cut(content, "\n")
+	return strings.TrimSpace(summary), strings.TrimSpace(description)
+}
+
+// runEditor opens path in $VISUAL or $EDITOR, which may include arguments
+// such as "code --wait".
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", editor+" "+path)
+	} else {
+		cmd = exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+
+	editCmd.Flags().BoolP("editor", "e", false, "Edit the summary and description in $EDITOR")
+	editCmd.Flags().StringP("summary", "s", "", "New summary")
+	editCmd.Flags().StringP("description", "d", "", "New description in Markdown (empty to clear)")
+	editCmd.Flags().String("description-file", "", "Read the new description from a file, or '-' for stdin")
+	editCmd.Flags().String("priority", "", "New priority name (e.g. High)")
+	editCmd.Flags().String("due", "", "Due date as YYYY-MM-DD (empty to clear)")
+	editCmd.Flags().StringArray("add-label", nil, "Add a label (repeatable)")
+	editCmd.Flags().StringArray("remove-label", nil, "Remove a label (repeatable)")
+	editCmd.Flags().StringArray("add-component", nil, "Add a component by name (repeatable)")
+	editCmd.Flags().StringArray("remove-component", nil, "Remove a component by name (repeatable)")
+	editCmd.Flags().StringArray("add-fix-version", nil, "Add a fix version by name (repeatable)")
+	editCmd.Flags().StringArray("remove-fix-version", nil, "Remove a fix version by name (repeatable)")
+	editCmd.Flags().StringArray("field", nil, "Set any field as name=value, by display name or ID (repeatable)")
+	editCmd.Flags().StringArray("add", nil, "Add an item to a multi-valued field as name=value (repeatable)")
+	editCmd.Flags().StringArray("remove", nil, "Remove an item from a multi-valued field as name=value (repeatable)")
+}
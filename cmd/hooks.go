@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/git"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// postCommitTimeout bounds how long the post-commit hook holds up git
+// commit while commenting.
+const postCommitTimeout = 10 * time.Second
+
+// scissorsLine ends the part of a commit message git keeps when
+// committing with --verbose.
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage the git hooks that tie commits to tickets",
+	Long: `Install git hooks in the current repository that tie commits to tickets.
+
+The commit-msg hook puts the ticket key of the current branch (see
+branch_key_regex) in front of commit messages that don't mention a
+ticket, so "Fix login" on feature/PROJ-123-login becomes
+"PROJ-123: Fix login". With --validate it rejects those commits instead.
+
+With --post-commit, a post-commit hook also comments on each ticket a new
+commit mentions, with the commit's SHA and subject. Amending, rebasing
+and cherry-picking don't comment again.
+
+Examples:
+  jira hooks install
+  jira hooks install --validate
+  jira hooks install --post-commit
+  jira hooks uninstall`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the commit-msg hook (and optionally post-commit)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		validate, _ := cmd.Flags().GetBool("validate")
+		postCommit, _ := cmd.Flags().GetBool("post-commit")
+		force, _ := cmd.Flags().GetBool("force")
+
+		repo, err := git.Open("")
+		ui.FatalIfError(err, "Error opening repository")
+		jira := hookCommandLine(cmd)
+
+		commitMsg := jira + " hooks commit-msg"
+		if validate {
+			commitMsg += " --validate"
+		}
+		var doc ui.HooksJSON
+		path, err := repo.InstallHook("commit-msg", "exec "+commitMsg+` "$1"`, force)
+		ui.FatalIfError(err, "Error installing commit-msg hook")
+		doc.Installed = append(doc.Installed, path)
+
+		if postCommit {
+			path, err := repo.InstallHook("post-commit", "exec "+jira+" hooks post-commit", force)
+			ui.FatalIfError(err, "Error installing post-commit hook")
+			doc.Installed = append(doc.Installed, path)
+		} else {
+			// Installing again without --post-commit turns commenting off.
+			removed, err := repo.RemoveHook("post-commit")
+			if !errors.Is(err, git.ErrForeignHook) {
+				ui.FatalIfError(err, "Error removing post-commit hook")
+			}
+			if removed {
+				doc.Removed = append(doc.Removed, "post-commit")
+			}
+		}
+
+		message := ""
+		for _, path := range doc.Installed {
+			message += fmt.Sprintf("✅ Installed %s\n", path)
+		}
+		for _, name := range doc.Removed {
+			message += fmt.Sprintf("Removed the %s hook\n", name)
+		}
+		ui.Result(doc, "%s", message)
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the hooks jira installed",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		repo, err := git.Open("")
+		ui.FatalIfError(err, "Error opening repository")
+
+		var doc ui.HooksJSON
+		for _, name := range []string{"commit-msg", "post-commit"} {
+			removed, err := repo.RemoveHook(name)
+			if errors.Is(err, git.ErrForeignHook) {
+				continue
+			}
+			ui.FatalIfError(err, "Error removing "+name+" hook")
+			if removed {
+				doc.Removed = append(doc.Removed, name)
+			}
+		}
+
+		if len(doc.Removed) == 0 {
+			ui.Result(doc, "No jira hooks are installed\n")
+			return
+		}
+		ui.Result(doc, "✅ Removed the %s hook(s)\n", strings.Join(doc.Removed, " and "))
+	},
+}
+
+var hooksCommitMsgCmd = &cobra.Command{
+	Use:    "commit-msg [message-file]",
+	Short:  "Run by the commit-msg hook",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		validate, _ := cmd.Flags().GetBool("validate")
+
+		data, err := os.ReadFile(args[0])
+		ui.FatalIfError(err, "Error reading commit message")
+		lines := strings.Split(string(data), "\n")
+		text := messageText(lines)
+		if strings.TrimSpace(text) == "" || skipsTicketCheck(text) {
+			return
+		}
+
+		cfg, err := hookConfig()
+		if err != nil {
+			if validate {
+				ui.FatalIfError(err, "Error loading config")
+			}
+			fmt.Fprintf(os.Stderr, "jira: not checking the ticket key: %v\n", err)
+			return
+		}
+		client := cfg.NewAPIClient()
+		mentioned := git.MessageKeys(text)
+
+		if validate {
+			projects := newProjectSet(ctx, cfg, client)
+			for _, key := range mentioned {
+				known, err := projects.has(git.ProjectOf(key))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "jira: can't check %s: %v\n", key, err)
+					return
+				}
+				if known {
+					return
+				}
+			}
+			hint := ""
+			if key, _, err := branchTicketKey(ctx, cfg, client); err == nil {
+				hint = fmt.Sprintf(`, e.g. "%s: %s"`, key, strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+			}
+			ui.FatalError("commit message doesn't mention a ticket%s", hint)
+		}
+
+		if len(mentioned) > 0 {
+			return
+		}
+		key, _, err := branchTicketKey(ctx, cfg, client)
+		if err != nil {
+			return
+		}
+		for i, line := range lines {
+			if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "#") {
+				lines[i] = key + ": " + line
+				break
+			}
+		}
+		err = os.WriteFile(args[0], []byte(strings.Join(lines, "\n")), 0o644)
+		ui.FatalIfError(err, "Error writing commit message")
+	},
+}
+
+var hooksPostCommitCmd = &cobra.Command{
+	Use:    "post-commit",
+	Short:  "Run by the post-commit hook",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		// Nothing here can undo the commit, so problems are only reported.
+		warn := func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, "jira: "+format+"\n", args...)
+		}
+
+		repo, err := git.Open("")
+		if err != nil {
+			warn("%v", err)
+			return
+		}
+		if !isNewCommit(repo) {
+			return
+		}
+		head, err := repo.Head()
+		if err != nil {
+			warn("%v", err)
+			return
+		}
+		keys := git.MessageKeys(head.Message())
+		if len(keys) == 0 {
+			return
+		}
+		cfg, err := hookConfig()
+		if err != nil {
+			warn("not commenting on %s: %v", strings.Join(keys, ", "), err)
+			return
+		}
+		// Commenting happens while git commit waits, so fail fast rather
+		// than retry; a missed comment can be added by hand.
+		client := cfg.NewAPIClient()
+		client.Retry = api.RetryPolicy{}
+		ctx, cancel := context.WithTimeout(ctx, postCommitTimeout)
+		defer cancel()
+
+		branch, _ := repo.CurrentBranch()
+		comment := fmt.Sprintf("Commit `%s`: %s", head.Short(), head.Subject)
+		if branch != "" {
+			comment = fmt.Sprintf("Commit `%s` on branch `%s`: %s", head.Short(), branch, head.Subject)
+		}
+
+		projects := newProjectSet(ctx, cfg, client)
+		for _, key := range keys {
+			known, err := projects.has(git.ProjectOf(key))
+			if err != nil {
+				warn("not commenting on %s: %v", key, err)
+				continue
+			}
+			if !known {
+				continue
+			}
+			if err := client.AddCommentContext(ctx, key, comment); err != nil {
+				warn("commenting on %s: %v", key, err)
+				continue
+			}
+			ui.Progress("Commented on %s\n", key)
+		}
+	},
+}
+
+// isNewCommit reports whether the post-commit hook runs for a commit just
+// written, rather than one amended or replayed by a rebase or cherry-pick,
+// whose tickets were already told about the original.
+func isNewCommit(repo *git.Repo) bool {
+	if action := os.Getenv("GIT_REFLOG_ACTION"); action != "" && !strings.HasPrefix(action, "commit") {
+		return false
+	}
+	if rebasing, err := repo.Rebasing(); err != nil || rebasing {
+		return false
+	}
+	action, err := repo.LastHeadAction()
+	if err != nil {
+		return false
+	}
+	// Without a reflog there's no telling, so only an unknown action counts.
+	return action == "" || action == "commit" || action == "commit (initial)"
+}
+
+// hookCommandLine is how the hooks run this jira: by its absolute path,
+// with the config file and profile it was installed with.
+func hookCommandLine(cmd *cobra.Command) string {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "jira"
+	}
+	parts := []string{shellQuote(exe)}
+	if cfgFile != "" {
+		if abs, err := filepath.Abs(cfgFile); err == nil {
+			parts = append(parts, "--config", shellQuote(abs))
+		}
+	}
+	if profile, _ := cmd.Flags().GetString("profile"); profile != "" {
+		parts = append(parts, "--profile", shellQuote(profile))
+	}
+	return strings.Join(parts, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// hookConfig loads the config without exiting, so a hook doesn't stop
+// commits when jira isn't set up.
+func hookConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := config.ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// messageText returns the commit message without git's comment lines.
+func messageText(lines []string) string {
+	var kept []string
+	for _, line := range lines {
+		if line == scissorsLine {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// skipsTicketCheck reports whether a message is one git writes, such as a
+// merge, or one that will be squashed into another commit.
+func skipsTicketCheck(text string) bool {
+	subject := strings.TrimSpace(text)
+	for _, prefix := range []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksCommitMsgCmd)
+	hooksCmd.AddCommand(hooksPostCommitCmd)
+
+	hooksInstallCmd.Flags().Bool("validate", false, "reject commit messages without a ticket key instead of adding one")
+	hooksInstallCmd.Flags().Bool("post-commit", false, "also comment on the tickets a commit mentions")
+	hooksInstallCmd.Flags().Bool("force", false, "replace hooks that jira didn't install")
+	hooksCommitMsgCmd.Flags().Bool("validate", false, "reject the message instead of adding the key")
+}
@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -17,13 +17,23 @@ This command will guide you through setting up:
 - Jira instance URL
 - Authentication (email + API token)
 - Default project
-- Other preferences`,
+- Other preferences
+
+Settings are stored as a named profile; use --profile to set up an
+additional Jira instance alongside your existing one.
+
+Examples:
+  jira init                    # Set up the active (or "default") profile
+  jira init --profile onprem   # Add or update the "onprem" profile`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Initializing JiraCLI configuration...")
+		ui.Progress("Initializing JiraCLI configuration...\n")
+
+		err := config.InitializeConfig(config.ActiveProfile())
+		ui.FatalIfError(err, "Error initializing config")
 
-		if err := config.InitializeConfig(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error initializing config: %v\n", err)
-			os.Exit(1)
+		if ui.JSONOutput() {
+			ui.PrintJSON(map[string]interface{}{"initialized": true, "profile": config.ActiveProfile()})
+			return
 		}
 
 		fmt.Println("\n✓ Configuration initialized successfully!")
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link [ticket-key] [relation] [other-ticket-key]",
+	Short: "Link two tickets",
+	Long: `Link two Jira tickets so that the sentence "KEY1 relation KEY2" reads true.
+
+The relation is matched loosely against your instance's link types, in
+either direction: "blocks", "blocked by", "is blocked by", "dup",
+"relates" and so on.
+
+Examples:
+  jira link PROJ-1 blocks PROJ-2
+  jira link PROJ-1 "is blocked by" PROJ-2
+  jira link PROJ-3 duplicates PROJ-1
+  jira link PROJ-4 relates PROJ-5`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 3, true)
+		fromKey := args[0]
+		toKey := args[len(args)-1]
+		phrase := strings.Join(args[1:len(args)-1], " ")
+
+		ui.Progress("Linking %s to %s...\n", fromKey, toKey)
+		relation, err := client.LinkIssuesContext(ctx, fromKey, phrase, toKey)
+		ui.FatalIfError(err, "Error linking tickets")
+
+		ui.Result(ui.ActionJSON{Key: fromKey, Action: "link", Relation: relation.Description(), Target: toKey},
+			"Successfully linked: %s %s %s\n", fromKey, relation.Description(), toKey)
+	},
+}
+
+var unlinkCmd = &cobra.Command{
+	Use:   "unlink [ticket-key] [other-ticket-key] [relation]",
+	Short: "Remove links between two tickets",
+	Long: `Remove the links between two Jira tickets.
+
+Without a relation every link between the two tickets is removed.
+
+Examples:
+  jira unlink PROJ-1 PROJ-2            # Remove all links between them
+  jira unlink PROJ-1 PROJ-2 blocks     # Only remove "PROJ-1 blocks PROJ-2"`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		// The other ticket comes after the key, so it tells whether the key was left out.
+		if len(args) < 2 || !looksLikeTicketKey(args[1]) {
+			args = prependBranchTicket(ctx, cfg, client, args)
+		}
+		fromKey := args[0]
+		toKey := args[1]
+		phrase := strings.Join(args[2:], " ")
+
+		ui.Progress("Unlinking %s from %s...\n", fromKey, toKey)
+		removed, err := client.UnlinkIssuesContext(ctx, fromKey, toKey, phrase)
+		ui.FatalIfError(err, "Error unlinking tickets")
+
+		var relations []string
+		for _, link := range removed {
+			description, _ := link.Relation()
+			relations = append(relations, description)
+		}
+		ui.Result(ui.ActionJSON{Key: fromKey, Action: "unlink", Relation: strings.Join(relations, ", "), Target: toKey},
+			"Successfully removed: %s %s %s\n", fromKey, strings.Join(relations, ", "), toKey)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+}
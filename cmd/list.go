@@ -2,9 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"net/url"
+	"strconv"
 	"strings"
 
+	"github.com/danielyan21/JiraCLI/internal/api"
 	"github.com/danielyan21/JiraCLI/internal/config"
 	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
@@ -22,22 +23,39 @@ Examples:
   jira list --recent             # Recently updated (last 7 days)
   jira list -p KAN               # All tickets in KAN project
   jira list -s "In Progress"     # Tickets with specific status
-  jira list -a @me -s Done       # Your done tickets`,
+  jira list -a @me -s Done       # Your done tickets
+  jira list --all -l 0           # Every ticket in the project, across all pages
+  jira list --sprint current     # Your tickets in the active sprint
+  jira list --sprint next --all  # Everything planned for the next sprint`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
-		jql := buildJQLQuery(cmd, cfg)
-		limit, _ := cmd.Flags().GetInt("limit")
 		client := cfg.NewAPIClient()
 
-		fmt.Println("Fetching tickets...")
-		results, err := client.SearchIssues(url.QueryEscape(jql), limit)
+		var sprintClause string
+		if ref, _ := cmd.Flags().GetString("sprint"); ref != "" {
+			sprints, err := resolveSprints(ctx, cmd, client, cfg, ref)
+			ui.FatalIfError(err, "Error finding sprint")
+			sprintClause = sprintJQL(sprints)
+		}
+
+		jql := buildJQLQuery(cmd, cfg, sprintClause)
+		limit, _ := cmd.Flags().GetInt("limit")
+		if allPages, _ := cmd.Flags().GetBool("all-pages"); allPages {
+			limit = 0
+		}
+
+		ui.Progress("Fetching tickets...\n")
+		results, err := client.SearchIssuesContext(ctx, jql, limit)
 		ui.FatalIfError(err, "Error fetching tickets")
 
 		ui.RenderIssueList(results)
 	},
 }
 
-func buildJQLQuery(cmd *cobra.Command, cfg *config.Config) string {
+// buildJQLQuery turns the filter flags into JQL; sprintClause, if set, is
+// the already resolved --sprint condition.
+func buildJQLQuery(cmd *cobra.Command, cfg *config.Config, sprintClause string) string {
 	mine, _ := cmd.Flags().GetBool("mine")
 	all, _ := cmd.Flags().GetBool("all")
 	recent, _ := cmd.Flags().GetBool("recent")
@@ -75,6 +93,10 @@ func buildJQLQuery(cmd *cobra.Command, cfg *config.Config) string {
 		}
 	}
 
+	if sprintClause != "" {
+		jqlParts = append(jqlParts, sprintClause)
+	}
+
 	if len(jqlParts) == 0 {
 		jqlParts = append(jqlParts, "assignee = currentUser()")
 	}
@@ -82,6 +104,17 @@ func buildJQLQuery(cmd *cobra.Command, cfg *config.Config) string {
 	return strings.Join(jqlParts, " AND ") + " ORDER BY status ASC, updated DESC"
 }
 
+func sprintJQL(sprints []api.Sprint) string {
+	if len(sprints) == 1 {
+		return fmt.Sprintf("sprint = %d", sprints[0].ID)
+	}
+	ids := make([]string, len(sprints))
+	for i, sprint := range sprints {
+		ids[i] = strconv.Itoa(sprint.ID)
+	}
+	return fmt.Sprintf("sprint in (%s)", strings.Join(ids, ", "))
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 
@@ -91,5 +124,8 @@ func init() {
 	listCmd.Flags().StringP("project", "p", "", "filter by project key")
 	listCmd.Flags().StringP("status", "s", "", "filter by status")
 	listCmd.Flags().StringP("assignee", "a", "", "filter by assignee (@me for yourself)")
-	listCmd.Flags().IntP("limit", "l", 20, "maximum number of tickets to show")
+	listCmd.Flags().IntP("limit", "l", 20, "maximum number of tickets to show (0 for no limit)")
+	listCmd.Flags().Bool("all-pages", false, "fetch every matching ticket, ignoring --limit")
+	listCmd.Flags().String("sprint", "", "filter by sprint: current, next, a sprint ID or name")
+	listCmd.Flags().Int("board", 0, "agile board for --sprint (default from default_board or the default project)")
 }
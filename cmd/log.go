@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log [ticket-key] [duration] [message]",
+	Short: "Log time spent on a ticket",
+	Long: `Log time spent on a Jira ticket.
+
+The duration uses Jira's syntax: a number followed by w, d, h or m, e.g.
+1h30m, 45m, 2d or 1.5h. By default the work started now and Jira reduces
+the remaining estimate by the time logged.
+
+Examples:
+  jira log PROJ-123 1h30m "Reviewed the migration"
+  jira log PROJ-123 45m --started 09:15
+  jira log PROJ-123 2h --started "2025-06-02 14:00" --leave-estimate
+  jira log PROJ-123 3h --new-estimate 1d`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 2, true)
+		ticketKey := args[0]
+
+		opts := api.WorklogOptions{
+			TimeSpent: args[1],
+			Comment:   strings.Join(args[2:], " "),
+		}
+		_, err := api.NormalizeDuration(opts.TimeSpent)
+		ui.FatalIfError(err, "Invalid duration")
+
+		if started, _ := cmd.Flags().GetString("started"); started != "" {
+			opts.Started, err = parseStarted(started, time.Now())
+			ui.FatalIfError(err, "Invalid --started")
+		}
+		opts.Estimate, err = estimateFromFlags(cmd.Flags(), "reduce-by")
+		ui.FatalIfError(err, "Invalid flags")
+
+		ui.Progress("Logging %s on %s...\n", opts.TimeSpent, ticketKey)
+		worklog, err := client.AddWorklogContext(ctx, ticketKey, opts)
+		ui.FatalIfError(err, "Error logging time")
+
+		doc := ui.NewWorklogJSON(worklog)
+		ui.Result(ui.ActionJSON{Key: ticketKey, Action: "log", Comment: opts.Comment, Worklog: &doc},
+			"✅ Logged %s on %s (worklog %s)\n", ui.FormatDuration(worklog.TimeSpentSeconds), ticketKey, worklog.ID)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+	logCmd.Flags().String("started", "", "When the work started, e.g. 14:00, 2025-06-02, \"2025-06-02 14:00\" or \"yesterday 16:30\"")
+	addEstimateFlags(logCmd, "reduce-by", "Reduce the remaining estimate by this much instead of the time logged")
+}
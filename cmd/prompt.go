@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"golang.org/x/term"
+)
+
+// stdinIsTerminal reports whether prompts can be shown. Scripts, pipes and
+// CI runs get a clean error for missing values instead of a hung prompt.
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// ask wraps survey.AskOne so Ctrl+C surfaces as context.Canceled and exits
+// with the interrupted status like every other command.
+func ask(prompt survey.Prompt, response interface{}, opts ...survey.AskOpt) error {
+	err := survey.AskOne(prompt, response, opts...)
+	if errors.Is(err, terminal.InterruptErr) {
+		return context.Canceled
+	}
+	return err
+}
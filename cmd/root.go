@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
+	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -21,12 +24,20 @@ Features:
 - Advanced search with JQL support
 - Git integration for automatic ticket linking
 - Local caching for instant responses
-- Terminal UI for interactive workflows`,
+- Terminal UI for interactive workflows
+
+Commands taking a ticket key use the one in the current git branch's name
+when it's left out, e.g. PROJ-123 on feature/PROJ-123-fix-login. Set
+branch_key_regex in the config to change how keys are found.`,
 	Version: "0.1.0",
 }
 
 func Execute() {
-	err := rootCmd.Execute()
+	// Ctrl-C cancels the command context, which aborts in-flight API requests.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
 	if err != nil {
 		os.Exit(1)
 	}
@@ -35,13 +46,17 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.jira-cli.yaml)")
-	rootCmd.PersistentFlags().String("profile", "default", "configuration profile to use")
+	rootCmd.PersistentFlags().String("profile", "", "configuration profile to use (default from JIRA_PROFILE or the config file)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolP("json", "j", false, "output in JSON format")
+	rootCmd.PersistentFlags().Bool("offline", false, "answer from the local cache only, without contacting Jira")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "always fetch from Jira (the local cache is still refreshed)")
 
 	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	viper.BindPFlag("json", rootCmd.PersistentFlags().Lookup("json"))
+	viper.BindPFlag("offline", rootCmd.PersistentFlags().Lookup("offline"))
+	viper.BindPFlag("no_cache", rootCmd.PersistentFlags().Lookup("no-cache"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -68,4 +83,6 @@ func initConfig() {
 			fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 		}
 	}
+
+	ui.SetJSONOutput(viper.GetBool("json"))
 }
@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Work with Scrum sprints",
+	Long: `List, inspect, plan, start and close sprints.
+
+Sprints are looked up on the board given by --board, then the profile's
+default_board, then the default project's only Scrum board. A sprint can be
+named by ID, by name, or as "current" (the active sprint) or "next" (the
+first future sprint).
+
+Examples:
+  jira sprint list
+  jira sprint view                    # Issues in the current sprint
+  jira sprint add next PROJ-1 PROJ-2  # Plan issues into the next sprint
+  jira sprint remove PROJ-2           # Move an issue back to the backlog
+  jira sprint start next --duration 2w --goal "Ship search"
+  jira sprint close --move-to next`,
+}
+
+var sprintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List a board's sprints",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		states, _ := cmd.Flags().GetStringSlice("state")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		boardID, err := resolveBoard(ctx, cmd, client, cfg)
+		ui.FatalIfError(err, "Error finding board")
+
+		ui.Progress("Fetching sprints...\n")
+		sprints, err := client.GetSprintsContext(ctx, boardID, states...)
+		ui.FatalIfError(err, "Error fetching sprints")
+
+		ui.RenderSprints(sprints)
+	},
+}
+
+var sprintViewCmd = &cobra.Command{
+	Use:   "view [sprint]",
+	Short: "Show a sprint and its issues (default the current sprint)",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		ref := "current"
+		if len(args) == 1 {
+			ref = args[0]
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		sprint, err := resolveOneSprint(ctx, cmd, client, cfg, ref)
+		ui.FatalIfError(err, "Error finding sprint")
+
+		ui.Progress("Fetching issues in %s...\n", sprint.Name)
+		results, err := client.GetSprintIssuesContext(ctx, sprint.ID, "", limit)
+		ui.FatalIfError(err, "Error fetching sprint issues")
+
+		ui.RenderSprintIssues(sprint, results)
+	},
+}
+
+var sprintAddCmd = &cobra.Command{
+	Use:   "add [sprint] [ticket-key...]",
+	Short: "Move tickets into a sprint",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		keys := args[1:]
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		sprint, err := resolveOneSprint(ctx, cmd, client, cfg, args[0])
+		ui.FatalIfError(err, "Error finding sprint")
+
+		ui.Progress("Moving %s to %s...\n", strings.Join(keys, ", "), sprint.Name)
+		err = client.MoveIssuesToSprintContext(ctx, sprint.ID, keys)
+		ui.FatalIfError(err, "Error moving tickets")
+
+		ui.Result(ui.ActionJSON{Key: strings.Join(keys, ","), Action: "sprint-add", Target: sprint.Name},
+			"✅ Moved %d ticket(s) to %s\n", len(keys), sprint.Name)
+	},
+}
+
+var sprintRemoveCmd = &cobra.Command{
+	Use:   "remove [ticket-key...]",
+	Short: "Move tickets out of their sprint into the backlog",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		ui.Progress("Moving %s to the backlog...\n", strings.Join(args, ", "))
+		err := client.MoveIssuesToBacklogContext(ctx, args)
+		ui.FatalIfError(err, "Error moving tickets")
+
+		ui.Result(ui.ActionJSON{Key: strings.Join(args, ","), Action: "sprint-remove", Target: "backlog"},
+			"✅ Moved %d ticket(s) to the backlog\n", len(args))
+	},
+}
+
+var sprintStartCmd = &cobra.Command{
+	Use:   "start [sprint]",
+	Short: "Start a future sprint (default the next one)",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		ref := "next"
+		if len(args) == 1 {
+			ref = args[0]
+		}
+		goal, _ := cmd.Flags().GetString("goal")
+
+		start, end, err := sprintDatesFromFlags(cmd, time.Now())
+		ui.FatalIfError(err, "Invalid dates")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		sprint, err := resolveOneSprint(ctx, cmd, client, cfg, ref)
+		ui.FatalIfError(err, "Error finding sprint")
+		if sprint.State != api.SprintFuture {
+			ui.FatalError("%s is %s; only future sprints can be started", sprint.Name, sprint.State)
+		}
+
+		ui.Progress("Starting %s...\n", sprint.Name)
+		started, err := client.StartSprintContext(ctx, sprint.ID, start, end, goal)
+		ui.FatalIfError(err, "Error starting sprint")
+
+		ui.Result(ui.NewSprintJSON(started), "✅ Started %s, ending %s\n", started.Name, end.Format("Mon 2006-01-02"))
+	},
+}
+
+var sprintCloseCmd = &cobra.Command{
+	Use:   "close [sprint]",
+	Short: "Close the active sprint",
+	Long: `Close a sprint (default the current one).
+
+Unfinished tickets go to the backlog, or with --move-to into another sprint,
+such as "next".`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		ref := "current"
+		if len(args) == 1 {
+			ref = args[0]
+		}
+		moveTo, _ := cmd.Flags().GetString("move-to")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		sprint, err := resolveOneSprint(ctx, cmd, client, cfg, ref)
+		ui.FatalIfError(err, "Error finding sprint")
+		if sprint.State != api.SprintActive {
+			ui.FatalError("%s is %s; only active sprints can be closed", sprint.Name, sprint.State)
+		}
+
+		var moved []string
+		if moveTo != "" && moveTo != "backlog" {
+			target, err := resolveOneSprint(ctx, cmd, client, cfg, moveTo)
+			ui.FatalIfError(err, "Error finding --move-to sprint")
+			if target.ID == sprint.ID {
+				ui.FatalError("can't move unfinished tickets into the sprint being closed")
+			}
+
+			unfinished, err := client.GetSprintIssuesContext(ctx, sprint.ID, "statusCategory != Done", 0)
+			ui.FatalIfError(err, "Error fetching unfinished tickets")
+			for _, issue := range unfinished.Issues {
+				moved = append(moved, issue.Key)
+			}
+			if len(moved) > 0 {
+				ui.Progress("Moving %d unfinished ticket(s) to %s...\n", len(moved), target.Name)
+				err = client.MoveIssuesToSprintContext(ctx, target.ID, moved)
+				ui.FatalIfError(err, "Error moving unfinished tickets")
+			}
+			moveTo = target.Name
+		}
+
+		ui.Progress("Closing %s...\n", sprint.Name)
+		closed, err := client.CloseSprintContext(ctx, sprint.ID)
+		ui.FatalIfError(err, "Error closing sprint")
+
+		message := fmt.Sprintf("✅ Closed %s\n", closed.Name)
+		if len(moved) > 0 {
+			message += fmt.Sprintf("Moved %d unfinished ticket(s) to %s\n", len(moved), moveTo)
+		}
+		ui.Result(ui.NewSprintJSON(closed), "%s", message)
+	},
+}
+
+// resolveBoard returns the board sprint commands work on: --board, then the
+// profile's default_board, then the default project's only Scrum board.
+func resolveBoard(ctx context.Context, cmd *cobra.Command, client *api.Client, cfg *config.Config) (int, error) {
+	if board, _ := cmd.Flags().GetInt("board"); board > 0 {
+		return board, nil
+	}
+	if cfg.DefaultBoard > 0 {
+		return cfg.DefaultBoard, nil
+	}
+	if cfg.DefaultProject == "" {
+		return 0, errors.New("no board chosen: pass --board, or set default_board or default_project in your profile")
+	}
+
+	boards, err := client.GetBoardsContext(ctx, cfg.DefaultProject, "scrum")
+	if err != nil {
+		return 0, err
+	}
+	switch len(boards) {
+	case 0:
+		return 0, fmt.Errorf("project %s has no Scrum board: %w", cfg.DefaultProject, api.ErrNotFound)
+	case 1:
+		return boards[0].ID, nil
+	}
+	var names []string
+	for _, board := range boards {
+		names = append(names, fmt.Sprintf("%d (%s)", board.ID, board.Name))
+	}
+	return 0, fmt.Errorf("project %s has several Scrum boards; pass --board or set default_board to one of %s",
+		cfg.DefaultProject, strings.Join(names, ", "))
+}
+
+// resolveSprints looks up a sprint reference: an ID, "current" or "active"
+// (every active sprint, as boards can run sprints in parallel), "next" (the
+// first future sprint) or a sprint name on the board.
+func resolveSprints(ctx context.Context, cmd *cobra.Command, client *api.Client, cfg *config.Config, ref string) ([]api.Sprint, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		sprint, err := client.GetSprintContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return []api.Sprint{*sprint}, nil
+	}
+
+	boardID, err := resolveBoard(ctx, cmd, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(ref) {
+	case "current", "active":
+		sprints, err := client.GetSprintsContext(ctx, boardID, api.SprintActive)
+		if err == nil && len(sprints) == 0 {
+			err = fmt.Errorf("board %d has no active sprint: %w", boardID, api.ErrNotFound)
+		}
+		return sprints, err
+	case "next":
+		sprints, err := client.GetSprintsContext(ctx, boardID, api.SprintFuture)
+		if err == nil && len(sprints) == 0 {
+			err = fmt.Errorf("board %d has no future sprint: %w", boardID, api.ErrNotFound)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return sprints[:1], nil
+	}
+
+	sprints, err := client.GetSprintsContext(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sprint := range sprints {
+		if strings.EqualFold(sprint.Name, ref) {
+			return []api.Sprint{sprint}, nil
+		}
+	}
+	return nil, fmt.Errorf("no sprint named %q on board %d: %w", ref, boardID, api.ErrNotFound)
+}
+
+// resolveOneSprint is resolveSprints for commands that act on one sprint.
+func resolveOneSprint(ctx context.Context, cmd *cobra.Command, client *api.Client, cfg *config.Config, ref string) (*api.Sprint, error) {
+	sprints, err := resolveSprints(ctx, cmd, client, cfg, ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(sprints) > 1 {
+		var names []string
+		for _, sprint := range sprints {
+			names = append(names, fmt.Sprintf("%d (%s)", sprint.ID, sprint.Name))
+		}
+		return nil, fmt.Errorf("%d sprints are active; pick one by ID: %s", len(sprints), strings.Join(names, ", "))
+	}
+	return &sprints[0], nil
+}
+
+// sprintDatesFromFlags reads --start, --end and --duration. The sprint
+// starts now and lasts two weeks unless told otherwise.
+func sprintDatesFromFlags(cmd *cobra.Command, now time.Time) (start, end time.Time, err error) {
+	flags := cmd.Flags()
+	start = now
+	if value, _ := flags.GetString("start"); value != "" {
+		if start, err = time.ParseInLocation(dateLayout, value, now.Location()); err != nil {
+			return start, end, fmt.Errorf("--start: expected YYYY-MM-DD, got %q", value)
+		}
+	}
+
+	if value, _ := flags.GetString("end"); value != "" {
+		if flags.Changed("duration") {
+			return start, end, errors.New("--end and --duration can't be used together")
+		}
+		if end, err = time.ParseInLocation(dateLayout, value, now.Location()); err != nil {
+			return start, end, fmt.Errorf("--end: expected YYYY-MM-DD, got %q", value)
+		}
+		end = end.Add(23*time.Hour + 59*time.Minute)
+	} else {
+		value, _ := flags.GetString("duration")
+		days, err := sprintLengthDays(value)
+		if err != nil {
+			return start, end, err
+		}
+		end = start.AddDate(0, 0, days)
+	}
+
+	if !end.After(start) {
+		return start, end, errors.New("the sprint must end after it starts")
+	}
+	return start, end, nil
+}
+
+// sprintLengthDays reads a sprint length such as "2w" or "10d".
+func sprintLengthDays(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) >= 2 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n > 0 {
+			switch value[len(value)-1] {
+			case 'w':
+				return n * 7, nil
+			case 'd':
+				return n, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid --duration %q: use weeks or days, e.g. 2w or 10d", value)
+}
+
+func init() {
+	rootCmd.AddCommand(sprintCmd)
+	sprintCmd.AddCommand(sprintListCmd, sprintViewCmd, sprintAddCmd, sprintRemoveCmd, sprintStartCmd, sprintCloseCmd)
+
+	sprintCmd.PersistentFlags().Int("board", 0, "agile board ID (default from default_board or the default project)")
+	sprintListCmd.Flags().StringSlice("state", []string{api.SprintActive, api.SprintFuture}, "sprint states to list: active, future, closed")
+	sprintViewCmd.Flags().IntP("limit", "l", 0, "maximum number of tickets to show (0 for no limit)")
+	sprintStartCmd.Flags().String("start", "", "start date as YYYY-MM-DD (default now)")
+	sprintStartCmd.Flags().String("end", "", "end date as YYYY-MM-DD")
+	sprintStartCmd.Flags().String("duration", "2w", "sprint length in weeks or days, e.g. 2w or 10d")
+	sprintStartCmd.Flags().StringP("goal", "g", "", "sprint goal")
+	sprintCloseCmd.Flags().String("move-to", "", "sprint for unfinished tickets, e.g. next (default the backlog)")
+}
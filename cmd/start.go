@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/danielyan21/JiraCLI/internal/api"
 	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/timer"
 	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -17,27 +22,59 @@ This is a quick action that combines:
   - Updating status to "In Progress"
   - Assigning the ticket to you
 
+With --timer a local timer starts too; "jira stop" or "jira done" log the
+elapsed time as a worklog.
+
 Examples:
-  jira start PROJ-123`,
-	Args: cobra.ExactArgs(1),
+  jira start PROJ-123
+  jira start PROJ-123 --timer`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ticketKey := args[0]
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
 		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 1, false)
+		ticketKey := args[0]
+		withTimer, _ := cmd.Flags().GetBool("timer")
 
-		fmt.Printf("Starting work on %s...\n", ticketKey)
-
-		if err := client.AssignIssue(ticketKey, "@me"); err != nil {
-			fmt.Printf("Warning: Could not assign ticket: %v\n", err)
+		// Check for a running timer first so a refusal changes nothing.
+		running, err := timer.Load()
+		ui.FatalIfError(err, "Error reading timer")
+		if withTimer && running != nil && !strings.EqualFold(running.Key, ticketKey) {
+			ui.FatalError("a timer is already running for %s; run 'jira stop' first", running.Key)
 		}
 
-		err := client.UpdateIssueStatus(ticketKey, "In Progress")
+		ui.Progress("Starting work on %s...\n", ticketKey)
+		warnings, err := startIssue(ctx, client, ticketKey)
 		ui.FatalIfError(err, "Error updating status")
 
-		fmt.Printf("✅ %s is now In Progress and assigned to you\n", ticketKey)
+		message := "✅ %s is now In Progress and assigned to you\n"
+		if withTimer {
+			if running == nil {
+				running = &timer.State{Key: ticketKey, Profile: cfg.Profile, Started: time.Now()}
+				ui.FatalIfError(timer.Save(running), "Error starting timer")
+			}
+			message += fmt.Sprintf("⏱  Timer running since %s; 'jira stop' logs the time\n", running.Started.Local().Format("15:04"))
+		}
+
+		ui.Result(ui.ActionJSON{Key: ticketKey, Action: "start", Status: "In Progress", Assignee: "@me", Warnings: warnings},
+			message, ticketKey)
 	},
 }
 
+// startIssue assigns the ticket to the user and moves it to In Progress.
+// Failing to assign is only a warning, as the ticket may already be taken
+// care of.
+func startIssue(ctx context.Context, client *api.Client, ticketKey string) ([]string, error) {
+	var warnings []string
+	if err := client.AssignIssueContext(ctx, ticketKey, "@me"); err != nil {
+		ui.Progress("Warning: Could not assign ticket: %v\n", err)
+		warnings = append(warnings, fmt.Sprintf("could not assign ticket: %v", err))
+	}
+	return warnings, client.UpdateIssueStatusContext(ctx, ticketKey, "In Progress")
+}
+
 func init() {
 	rootCmd.AddCommand(startCmd)
+	startCmd.Flags().Bool("timer", false, "Start a timer whose time is logged by 'jira stop' or 'jira done'")
 }
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/danielyan21/JiraCLI/internal/config"
 	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/cobra"
@@ -22,20 +20,23 @@ Examples:
   jira status PROJ-123 done          # Update to Done
   jira status PROJ-123 ip            # Update to In Progress
   jira status PROJ-123 "in progress" # With spaces (needs quotes)
-  jira status PROJ-123 td            # Update to To Do`,
-	Args: cobra.ExactArgs(2),
+  jira status PROJ-123 td            # Update to To Do
+  jira status ip                     # The ticket named by the current git branch`,
+	Args: ticketKeyArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		ticketKey := args[0]
-		newStatus := args[1]
-
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
 		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 2, false)
+		ticketKey := args[0]
+		newStatus := args[1]
 
-		fmt.Printf("Updating %s to '%s'...\n", ticketKey, newStatus)
-		err := client.UpdateIssueStatus(ticketKey, newStatus)
+		ui.Progress("Updating %s to '%s'...\n", ticketKey, newStatus)
+		err := client.UpdateIssueStatusContext(ctx, ticketKey, newStatus)
 		ui.FatalIfError(err, "Error updating status")
 
-		fmt.Printf("Successfully updated %s to '%s'\n", ticketKey, newStatus)
+		ui.Result(ui.ActionJSON{Key: ticketKey, Action: "status", Status: newStatus},
+			"Successfully updated %s to '%s'\n", ticketKey, newStatus)
 	},
 }
 
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"github.com/danielyan21/JiraCLI/internal/cache"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fill the local cache for fast and offline use",
+	Long: `Fetch tickets, their comments and Jira's metadata (fields, priorities,
+link types) into the local cache, so later commands answer instantly and
+work with --offline.
+
+Without --jql, the tickets jira list shows by default are synced; give
+--jql once per query to sync others. A query synced here can be run
+offline with jira list, jira sprint view or jira board as long as the
+JQL and a limit no bigger than the synced one match.
+
+Examples:
+  jira sync
+  jira sync --jql "project = PROJ AND sprint in openSprints()"
+  jira sync --no-comments -l 500`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		queries, _ := cmd.Flags().GetStringArray("jql")
+		limit, _ := cmd.Flags().GetInt("limit")
+		noComments, _ := cmd.Flags().GetBool("no-comments")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		if client.Cache == nil {
+			ui.FatalError("no cache directory is available")
+		}
+		if client.Cache.Mode == cache.ModeOffline {
+			ui.FatalError("jira sync needs a connection to Jira; drop --offline")
+		}
+		client.Cache.Mode = cache.ModeRefresh
+
+		if len(queries) == 0 {
+			// The same query jira list runs without flags, so it works offline.
+			queries = []string{buildJQLQuery(listCmd, cfg, "")}
+		}
+
+		ui.Progress("Fetching metadata...\n")
+		_, err := client.GetFieldsContext(ctx)
+		ui.FatalIfError(err, "Error fetching fields")
+		_, err = client.GetPrioritiesContext(ctx)
+		ui.FatalIfError(err, "Error fetching priorities")
+		_, err = client.GetIssueLinkTypesContext(ctx)
+		ui.FatalIfError(err, "Error fetching link types")
+
+		seen := map[string]bool{}
+		var keys []string
+		for _, jql := range queries {
+			ui.Progress("Fetching tickets for %s...\n", jql)
+			results, err := client.SearchIssuesContext(ctx, jql, limit)
+			ui.FatalIfError(err, "Error fetching tickets")
+			for _, issue := range results.Issues {
+				if !seen[issue.Key] {
+					seen[issue.Key] = true
+					keys = append(keys, issue.Key)
+				}
+			}
+		}
+
+		comments := 0
+		if !noComments {
+			ui.Progress("Fetching comments for %d ticket(s)...\n", len(keys))
+			for _, key := range keys {
+				_, err := client.GetCommentsContext(ctx, key)
+				ui.FatalIfError(err, "Error fetching comments for "+key)
+				comments++
+			}
+		}
+
+		doc := ui.SyncJSON{Dir: client.Cache.Dir, Queries: queries, Issues: len(keys), Comments: comments}
+		ui.Result(doc, "Cached %d ticket(s) and the comments of %d in %s\n", len(keys), comments, client.Cache.Dir)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().StringArray("jql", nil, "JQL query to sync (repeatable; default: the tickets jira list shows)")
+	syncCmd.Flags().IntP("limit", "l", 200, "maximum number of tickets per query (0 for no limit)")
+	syncCmd.Flags().Bool("no-comments", false, "don't fetch the tickets' comments")
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -15,29 +16,42 @@ var testCmd = &cobra.Command{
 	Long: `Test your Jira API credentials and connection.
 If a ticket key is provided, shows raw API response for debugging.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
 
 		if len(args) > 0 {
-			debugTicket(cfg, args[0])
+			debugTicket(ctx, cfg, args[0])
 			return
 		}
 
-		fmt.Println("Testing Jira API connection...")
-		fmt.Printf("URL: %s\n", cfg.JiraURL)
-		fmt.Printf("Email: %s\n", cfg.Email)
-		fmt.Println("API Token: [HIDDEN]")
-		fmt.Println()
-
 		authType := cfg.AuthType
 		if authType == "" {
 			authType = "basic"
 		}
-		fmt.Printf("Auth Type: %s\n", authType)
 
 		client := cfg.NewAPIClient()
 
+		if ui.JSONOutput() {
+			err := client.TestConnectionContext(ctx)
+			ui.FatalIfError(err, "Connection failed")
+			ui.PrintJSON(map[string]interface{}{
+				"ok":       true,
+				"url":      cfg.JiraURL,
+				"email":    cfg.Email,
+				"authType": authType,
+			})
+			return
+		}
+
+		fmt.Println("Testing Jira API connection...")
+		fmt.Printf("URL: %s\n", cfg.JiraURL)
+		fmt.Printf("Email: %s\n", cfg.Email)
+		fmt.Println("API Token: [HIDDEN]")
+		fmt.Println()
+		fmt.Printf("Auth Type: %s\n", authType)
+
 		fmt.Println("Attempting to connect...")
-		err := client.TestConnection()
+		err := client.TestConnectionContext(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n❌ Connection failed: %v\n\n", err)
 			fmt.Fprintln(os.Stderr, "Troubleshooting tips:")
@@ -49,7 +63,7 @@ If a ticket key is provided, shows raw API response for debugging.`,
 			fmt.Fprintln(os.Stderr, "  - Jira Cloud: https://id.atlassian.com/manage-profile/security/api-tokens")
 			fmt.Fprintln(os.Stderr, "  - Jira Server/DC: Use your username and password")
 			fmt.Fprintln(os.Stderr, "\nThen run 'jira init' to update your credentials")
-			os.Exit(1)
+			os.Exit(ui.ExitCode(err))
 		}
 
 		fmt.Println("✅ Connection successful!")
@@ -57,10 +71,18 @@ If a ticket key is provided, shows raw API response for debugging.`,
 	},
 }
 
-func debugTicket(cfg *config.Config, ticketKey string) {
+func debugTicket(ctx context.Context, cfg *config.Config, ticketKey string) {
 	client := cfg.NewAPIClient()
 	c := ui.NewColorFuncs()
 
+	// In JSON mode dump the decoded issue as-is; that is what debugging scripts want.
+	if ui.JSONOutput() {
+		issue, err := client.GetIssueContext(ctx, ticketKey)
+		ui.FatalIfError(err, "Error fetching ticket")
+		ui.PrintJSON(issue)
+		return
+	}
+
 	fmt.Printf("Fetching %s for debugging...\n\n", c.Cyan(ticketKey))
 
 	apiVersion := "3"
@@ -74,10 +96,10 @@ func debugTicket(cfg *config.Config, ticketKey string) {
 	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s", apiVersion, ticketKey)
 	fmt.Printf("%s %s\n\n", c.Bold("Endpoint:"), endpoint)
 
-	issue, err := client.GetIssue(ticketKey)
+	issue, err := client.GetIssueContext(ctx, ticketKey)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s %v\n", c.Red("Error:"), err)
-		os.Exit(1)
+		os.Exit(ui.ExitCode(err))
 	}
 
 	fmt.Printf("%s %s\n", c.Bold("Issue key:"), c.Cyan(issue.Key))
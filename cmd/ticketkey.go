@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/git"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ticketArgs returns args with the ticket key first, for commands taking
+// n arguments starting with one. When the key is left out, it's taken
+// from the current git branch. Commands taking more than n arguments
+// (variadic) only count the key as given when args[0] looks like one.
+func ticketArgs(ctx context.Context, cfg *config.Config, client *api.Client, args []string, n int, variadic bool) []string {
+	if len(args) >= n && (!variadic || looksLikeTicketKey(args[0])) {
+		return args
+	}
+	return prependBranchTicket(ctx, cfg, client, args)
+}
+
+// ticketKeyArgs validates the arguments of a command taking n of them,
+// starting with a ticket key that may be left out. With one argument
+// missing, a first argument that looks like a key means the user forgot
+// another one; taking the key from the branch would misuse it instead.
+func ticketKeyArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := cobra.RangeArgs(n-1, n)(cmd, args); err != nil {
+			return err
+		}
+		if len(args) == n-1 && len(args) > 0 && looksLikeTicketKey(args[0]) {
+			return fmt.Errorf("accepts %d arg(s) after the ticket key %s, received %d", n-1, args[0], len(args)-1)
+		}
+		return nil
+	}
+}
+
+// looksLikeTicketKey reports whether s has the form of a key like "PROJ-123".
+func looksLikeTicketKey(s string) bool {
+	return git.ProjectOf(strings.ToUpper(s)) != ""
+}
+
+// prependBranchTicket puts the ticket of the current git branch in front
+// of args, or exits explaining why there isn't one.
+func prependBranchTicket(ctx context.Context, cfg *config.Config, client *api.Client, args []string) []string {
+	key, branch, err := branchTicketKey(ctx, cfg, client)
+	ui.FatalIfError(err, "No ticket key given")
+	ui.Progress("Using %s from branch %s\n", key, branch)
+	return append([]string{key}, args...)
+}
+
+// branchTicketKey finds the ticket the current git branch is named after,
+// using the branch_key_regex setting. Keys of projects that don't exist
+// are skipped, so "release-2024-1" isn't taken for a ticket.
+func branchTicketKey(ctx context.Context, cfg *config.Config, client *api.Client) (key, branch string, err error) {
+	repo, err := git.Open("")
+	if errors.Is(err, git.ErrNotRepository) {
+		return "", "", errors.New("not in a git repository to take it from the branch")
+	}
+	if err != nil {
+		return "", "", err
+	}
+	branch, err = repo.CurrentBranch()
+	if err != nil {
+		return "", "", err
+	}
+	if branch == "" {
+		return "", "", errors.New("HEAD is detached, so there is no branch to take it from")
+	}
+
+	pattern, err := git.CompileKeyPattern(cfg.BranchKeyRegex)
+	if err != nil {
+		return "", "", err
+	}
+	keys := git.TicketKeys(branch, pattern)
+	if len(keys) == 0 {
+		return "", "", fmt.Errorf("branch %q doesn't name a ticket", branch)
+	}
+
+	projects := newProjectSet(ctx, cfg, client)
+	for _, key := range keys {
+		known, err := projects.has(git.ProjectOf(key))
+		if err != nil {
+			return "", "", fmt.Errorf("checking the projects of %s: %w", strings.Join(keys, ", "), err)
+		}
+		if known {
+			return key, branch, nil
+		}
+	}
+	return "", "", fmt.Errorf("%s in branch %q is not in a known project", strings.Join(keys, ", "), branch)
+}
+
+// projectSet tells which project keys exist, asking Jira only when the
+// default project doesn't settle it.
+type projectSet struct {
+	ctx    context.Context
+	client *api.Client
+	known  map[string]bool
+	loaded bool
+}
+
+func newProjectSet(ctx context.Context, cfg *config.Config, client *api.Client) *projectSet {
+	return &projectSet{ctx: ctx, client: client, known: map[string]bool{strings.ToUpper(cfg.DefaultProject): true}}
+}
+
+func (p *projectSet) has(project string) (bool, error) {
+	project = strings.ToUpper(project)
+	if project == "" {
+		return false, nil
+	}
+	if !p.known[project] && !p.loaded {
+		projects, err := p.client.GetProjectsContext(p.ctx)
+		if err != nil {
+			return false, err
+		}
+		for _, project := range projects {
+			p.known[strings.ToUpper(project.Key)] = true
+		}
+		p.loaded = true
+	}
+	return p.known[project], nil
+}
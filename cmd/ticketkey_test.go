@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestTicketKeyArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{[]string{"PROJ-1", "hello"}, false},
+		{[]string{"hello"}, false},            // key from the branch
+		{[]string{"ip"}, false},               // key from the branch
+		{[]string{"PROJ-9"}, true},            // the comment is missing
+		{[]string{"proj-9"}, true},            // keys are case-insensitive
+		{[]string{}, true},                    // too few
+		{[]string{"PROJ-1", "a", "b"}, true},  // too many
+		{[]string{"release-notes"}, false},    // no number, not a key
+		{[]string{"PROJ-1", "PROJ-2"}, false}, // both given
+		{[]string{"Fix PROJ-2 today"}, false}, // text mentioning a key
+	}
+	validate := ticketKeyArgs(2)
+	for _, tt := range tests {
+		err := validate(&cobra.Command{Use: "comment"}, tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ticketKeyArgs(2)(%q) = %v, want error %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTicketArgsKeepsGivenKey(t *testing.T) {
+	tests := []struct {
+		args     []string
+		n        int
+		variadic bool
+	}{
+		{[]string{"PROJ-1"}, 1, false},
+		{[]string{"PROJ-1", "done"}, 2, false},
+		{[]string{"PROJ-1", "1h", "fixing", "it"}, 2, true},
+		{[]string{"proj-1", "blocks", "PROJ-2"}, 3, true},
+	}
+	for _, tt := range tests {
+		// With the key given, neither the config nor Jira is needed.
+		got := ticketArgs(context.Background(), nil, nil, tt.args, tt.n, tt.variadic)
+		if len(got) != len(tt.args) || got[0] != tt.args[0] {
+			t.Errorf("ticketArgs(%q, %d, %v) = %q, want the arguments unchanged", tt.args, tt.n, tt.variadic, got)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/timer"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var timerCmd = &cobra.Command{
+	Use:   "timer",
+	Short: "Show the running work timer",
+	Long: `Track time on a ticket with a local timer.
+
+Start one with "jira start KEY --timer". "jira stop" or "jira done" log the
+elapsed time as a worklog, rounded by the profile's timer_rounding ("up",
+"nearest" or "down", default up) and timer_round_to (default 1m) settings.
+
+Examples:
+  jira start PROJ-123 --timer
+  jira timer status
+  jira stop -m "Fixed the flaky test"`,
+}
+
+var timerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which ticket the timer is running for",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		state, err := timer.Load()
+		ui.FatalIfError(err, "Error reading timer")
+
+		if state == nil {
+			ui.Result(ui.TimerJSON{}, "No timer running.\n")
+			return
+		}
+
+		cfg, err := config.LoadConfig()
+		ui.FatalIfError(err, "Error loading config")
+		rule, err := cfg.TimerRule()
+		ui.FatalIfError(err, "Invalid config")
+
+		elapsed := state.Elapsed(time.Now())
+		doc := ui.TimerJSON{
+			Running:        true,
+			Key:            state.Key,
+			Profile:        state.Profile,
+			Started:        &state.Started,
+			ElapsedSeconds: int(elapsed.Seconds()),
+			RoundedSeconds: int(rule.Apply(elapsed).Seconds()),
+		}
+		ui.Result(doc, "⏱  %s: %s since %s (%s when logged, rounded %s)\n",
+			state.Key, formatElapsed(elapsed), state.Started.Local().Format("2006-01-02 15:04"),
+			timer.FormatJira(rule.Apply(elapsed)), rule)
+	},
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop [ticket-key]",
+	Short: "Stop the work timer and log the time spent",
+	Long: `Stop the timer started by "jira start --timer" and log the elapsed time
+on its ticket, rounded by the timer_rounding and timer_round_to settings.
+
+The ticket key is optional; if given it must match the running timer.
+
+Examples:
+  jira stop
+  jira stop PROJ-123 -m "Reviewed the migration"
+  jira stop --discard                # Stop without logging anything`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		state, err := timer.Load()
+		ui.FatalIfError(err, "Error reading timer")
+		if state == nil {
+			ui.FatalError("no timer is running; start one with 'jira start KEY --timer'")
+		}
+		if len(args) == 1 && !strings.EqualFold(args[0], state.Key) {
+			ui.FatalError("the timer is running for %s, not %s", state.Key, args[0])
+		}
+
+		if discard, _ := cmd.Flags().GetBool("discard"); discard {
+			ui.FatalIfError(timer.Clear(), "Error stopping timer")
+			ui.Result(ui.ActionJSON{Key: state.Key, Action: "timer-discard"},
+				"Discarded the timer for %s (%s)\n", state.Key, formatElapsed(state.Elapsed(time.Now())))
+			return
+		}
+
+		comment, _ := cmd.Flags().GetString("comment")
+		estimate, err := estimateFromFlags(cmd.Flags(), "reduce-by")
+		ui.FatalIfError(err, "Invalid flags")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		worklog, err := logTimer(ctx, client, cfg, state, comment, estimate)
+		ui.FatalIfError(err, "Error logging time")
+
+		doc := ui.ActionJSON{Key: state.Key, Action: "stop", Comment: comment}
+		if worklog == nil {
+			ui.Result(doc, "Stopped the timer for %s; nothing to log after rounding\n", state.Key)
+			return
+		}
+		worklogDoc := ui.NewWorklogJSON(worklog)
+		doc.Worklog = &worklogDoc
+		ui.Result(doc, "✅ Logged %s on %s (worklog %s)\n", ui.FormatDuration(worklog.TimeSpentSeconds), state.Key, worklog.ID)
+	},
+}
+
+// logTimer logs the running timer's rounded time on its ticket and clears
+// the timer. It returns a nil worklog if the time rounded down to nothing.
+func logTimer(ctx context.Context, client *api.Client, cfg *config.Config, state *timer.State, comment string, estimate api.EstimateAdjustment) (*api.Worklog, error) {
+	if state.Profile != "" && state.Profile != cfg.Profile {
+		return nil, fmt.Errorf("the timer for %s was started with profile %q; pass --profile %s", state.Key, state.Profile, state.Profile)
+	}
+	rule, err := cfg.TimerRule()
+	if err != nil {
+		return nil, err
+	}
+
+	spent := rule.Apply(state.Elapsed(time.Now()))
+	if spent == 0 {
+		return nil, timer.Clear()
+	}
+
+	ui.Progress("Logging %s on %s...\n", timer.FormatJira(spent), state.Key)
+	worklog, err := client.AddWorklogContext(ctx, state.Key, api.WorklogOptions{
+		TimeSpent: timer.FormatJira(spent),
+		Started:   state.Started,
+		Comment:   comment,
+		Estimate:  estimate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return worklog, timer.Clear()
+}
+
+// formatElapsed renders a running time to the second, e.g. "1h 05m 12s".
+func formatElapsed(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%dh %02dm %02ds", h, m, s)
+	}
+	return fmt.Sprintf("%dm %02ds", m, s)
+}
+
+func init() {
+	rootCmd.AddCommand(timerCmd, stopCmd)
+	timerCmd.AddCommand(timerStatusCmd)
+
+	stopCmd.Flags().StringP("comment", "m", "", "Comment for the worklog")
+	stopCmd.Flags().Bool("discard", false, "Stop the timer without logging the time")
+	addEstimateFlags(stopCmd, "reduce-by", "Reduce the remaining estimate by this much instead of the time logged")
+}
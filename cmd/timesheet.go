@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+const dateLayout = "2006-01-02"
+
+var timesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Show the time you logged this week, per ticket and day",
+	Long: `Show the time you logged across all tickets, per ticket and per day.
+
+The report covers the current week (Monday to Sunday) unless you pick
+another range.
+
+Examples:
+  jira timesheet                     # This week
+  jira timesheet --week --last       # Last week
+  jira timesheet --from 2025-06-01 --to 2025-06-15
+  jira timesheet --json              # Totals in seconds, for scripts`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		from, to, err := timesheetRange(cmd, time.Now())
+		ui.FatalIfError(err, "Invalid date range")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		me, err := client.GetCurrentUserContext(ctx)
+		ui.FatalIfError(err, "Error fetching current user")
+
+		doc := &ui.TimesheetJSON{
+			User:      ui.NewUserJSON(me),
+			From:      from.Format(dateLayout),
+			To:        to.Format(dateLayout),
+			DayTotals: map[string]int{},
+		}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			doc.Days = append(doc.Days, day.Format(dateLayout))
+		}
+
+		jql := fmt.Sprintf(`worklogAuthor = currentUser() AND worklogDate >= "%s" AND worklogDate <= "%s" ORDER BY key ASC`,
+			doc.From, doc.To)
+		ui.Progress("Fetching worklogs from %s to %s...\n", doc.From, doc.To)
+		err = client.ForEachIssueContext(ctx, jql, 0, func(issue api.Issue) error {
+			worklogs, err := client.GetWorklogsContext(ctx, issue.Key)
+			if err != nil {
+				return fmt.Errorf("fetching worklogs for %s: %w", issue.Key, err)
+			}
+
+			row := ui.TimesheetIssueJSON{Key: issue.Key, Summary: issue.Fields.Summary, Days: map[string]int{}}
+			for _, worklog := range worklogs {
+				day := worklog.Started.Local().Format(dateLayout)
+				if !me.SameAs(worklog.Author) || day < doc.From || day > doc.To {
+					continue
+				}
+				row.Days[day] += worklog.TimeSpentSeconds
+				row.TotalSeconds += worklog.TimeSpentSeconds
+				doc.DayTotals[day] += worklog.TimeSpentSeconds
+				doc.TotalSeconds += worklog.TimeSpentSeconds
+			}
+			if row.TotalSeconds > 0 {
+				doc.Issues = append(doc.Issues, row)
+			}
+			return nil
+		})
+		ui.FatalIfError(err, "Error building timesheet")
+
+		ui.RenderTimesheet(doc)
+	},
+}
+
+// timesheetRange returns the first and last day of the report, at local
+// midnight.
+func timesheetRange(cmd *cobra.Command, now time.Time) (from, to time.Time, err error) {
+	flags := cmd.Flags()
+	fromFlag, _ := flags.GetString("from")
+	toFlag, _ := flags.GetString("to")
+	last, _ := flags.GetBool("last")
+
+	if fromFlag == "" && toFlag == "" {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		// Weeks start on Monday; time.Weekday counts from Sunday.
+		from = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		if last {
+			from = from.AddDate(0, 0, -7)
+		}
+		return from, from.AddDate(0, 0, 6), nil
+	}
+	if last || flags.Changed("week") {
+		return from, to, errors.New("--week and --last can't be used with --from/--to")
+	}
+
+	if from, err = time.ParseInLocation(dateLayout, fromFlag, now.Location()); err != nil {
+		return from, to, fmt.Errorf("--from: expected YYYY-MM-DD, got %q", fromFlag)
+	}
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if toFlag != "" {
+		if to, err = time.ParseInLocation(dateLayout, toFlag, now.Location()); err != nil {
+			return from, to, fmt.Errorf("--to: expected YYYY-MM-DD, got %q", toFlag)
+		}
+	}
+	if to.Before(from) {
+		return from, to, errors.New("--to is before --from")
+	}
+	return from, to, nil
+}
+
+func init() {
+	rootCmd.AddCommand(timesheetCmd)
+	timesheetCmd.Flags().BoolP("week", "w", true, "Report on a week, Monday to Sunday (the default)")
+	timesheetCmd.Flags().Bool("last", false, "Report on last week instead of this one")
+	timesheetCmd.Flags().String("from", "", "First day of a custom range (YYYY-MM-DD)")
+	timesheetCmd.Flags().String("to", "", "Last day of a custom range (YYYY-MM-DD, default today)")
+}
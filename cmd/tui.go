@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/tui"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [jql]",
+	Short: "Browse and triage tickets in a full-screen terminal UI",
+	Long: `Browse the tickets of a JQL query in a full-screen view.
+
+Move with the arrow keys or j/k and press Enter to open a ticket. Single
+keys act on the selected ticket: t transitions it, a assigns it, c adds a
+comment and o opens it in the browser. / filters the list as you type, r
+runs the query again and ? lists every key.
+
+Without a query, your unresolved tickets are shown.
+
+Examples:
+  jira tui
+  jira tui "project = PROJ AND sprint in openSprints()"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if ui.JSONOutput() {
+			ui.FatalError("jira tui is interactive and has no JSON output")
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+
+		jql := "assignee = currentUser() AND resolution = Unresolved ORDER BY updated DESC"
+		if len(args) > 0 {
+			jql = args[0]
+		}
+
+		err := tui.Run(cmd.Context(), tui.Options{
+			Client:  client,
+			JiraURL: cfg.JiraURL,
+			JQL:     jql,
+			Limit:   limit,
+		})
+		if errors.Is(err, tui.ErrNotTerminal) {
+			ui.FatalError("%v; use jira list instead", err)
+		}
+		ui.FatalIfError(err, "Error running the terminal UI")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+	tuiCmd.Flags().IntP("limit", "l", 500, "maximum number of tickets to load (0 for no limit)")
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// blockedByRelation is the link phrase block --by records and unblock
+// removes; it is matched against the instance's link types like any other.
+const blockedByRelation = "is blocked by"
+
+var unblockCmd = &cobra.Command{
+	Use:   "unblock [ticket-key]",
+	Short: "Clear a ticket's blockers and move it back to its previous status",
+	Long: `Remove a ticket's "is blocked by" links, move it back to the status it had
+before it was blocked (looked up in the ticket's history) and add a comment.
+
+With --by only that blocker's link is removed; if other blockers remain the
+ticket stays blocked. Use --to to pick the status yourself.
+
+Examples:
+  jira unblock PROJ-123
+  jira unblock PROJ-123 --by PROJ-99
+  jira unblock PROJ-123 --to "In Review" -m "API access granted"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 1, false)
+		ticketKey := args[0]
+		only, _ := cmd.Flags().GetStringArray("by")
+		targetStatus, _ := cmd.Flags().GetString("to")
+		comment, _ := cmd.Flags().GetString("comment")
+
+		ui.Progress("Unblocking %s...\n", ticketKey)
+		issue, err := client.GetFreshIssueContext(ctx, ticketKey)
+		ui.FatalIfError(err, "Error fetching ticket")
+
+		var warnings []string
+		var removed, remaining []string
+
+		relation, err := client.FindLinkRelationContext(ctx, blockedByRelation)
+		if err != nil {
+			ui.Progress("Warning: Could not look up the blocking link type: %v\n", err)
+			warnings = append(warnings, fmt.Sprintf("could not look up the blocking link type: %v", err))
+		}
+		for _, link := range issue.Fields.IssueLinks {
+			description, other := link.Relation()
+			if relation == nil || other == nil || link.Type.ID != relation.Type.ID || description != relation.Description() {
+				continue
+			}
+			if len(only) > 0 && !containsFold(only, other.Key) {
+				remaining = append(remaining, other.Key)
+				continue
+			}
+			if err := client.DeleteIssueLinkContext(ctx, link.ID); err != nil {
+				ui.Progress("Warning: Could not remove the link to %s: %v\n", other.Key, err)
+				warnings = append(warnings, fmt.Sprintf("could not remove the link to %s: %v", other.Key, err))
+				remaining = append(remaining, other.Key)
+				continue
+			}
+			removed = append(removed, other.Key)
+		}
+		for _, key := range only {
+			if !containsFold(removed, key) && !containsFold(remaining, key) {
+				warnings = append(warnings, fmt.Sprintf("%s was not blocking %s", key, ticketKey))
+				ui.Progress("Warning: %s was not blocking %s\n", key, ticketKey)
+			}
+		}
+
+		status := issue.Fields.Status.Name
+		if len(remaining) == 0 && (targetStatus != "" || strings.Contains(strings.ToLower(status), "block")) {
+			if targetStatus == "" {
+				targetStatus, err = client.PreviousStatusContext(ctx, ticketKey, status)
+				ui.FatalIfError(err, "Error reading ticket history")
+				if targetStatus == "" {
+					ui.FatalError("could not find the status %s had before %s; pass --to", ticketKey, status)
+				}
+			}
+
+			ui.Progress("Moving %s to %s...\n", ticketKey, targetStatus)
+			err = client.UpdateIssueStatusContext(ctx, ticketKey, targetStatus)
+			ui.FatalIfError(err, "Error updating status")
+			status = targetStatus
+		}
+
+		if comment == "" && len(remaining) == 0 {
+			comment = "Unblocked."
+			if len(removed) > 0 {
+				comment = fmt.Sprintf("Unblocked: no longer blocked by %s.", strings.Join(removed, ", "))
+			}
+		}
+		if comment != "" {
+			ui.Progress("Adding comment...\n")
+			if err := client.AddCommentContext(ctx, ticketKey, comment); err != nil {
+				ui.Progress("Warning: Could not add comment: %v\n", err)
+				warnings = append(warnings, fmt.Sprintf("could not add comment: %v", err))
+			}
+		}
+
+		doc := ui.ActionJSON{Key: ticketKey, Action: "unblock", Status: status, Comment: comment,
+			Target: strings.Join(removed, ", "), Warnings: warnings}
+		if len(removed) > 0 {
+			doc.Relation = relation.Description()
+		}
+		if len(remaining) > 0 {
+			ui.Result(doc, "%s is still blocked by %s; leaving it in %s\n", ticketKey, strings.Join(remaining, ", "), status)
+			return
+		}
+		ui.Result(doc, "✅ %s is unblocked and now %s\n", ticketKey, status)
+	},
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(unblockCmd)
+	unblockCmd.Flags().StringArrayP("by", "b", nil, "only remove this blocker's link (repeatable)")
+	unblockCmd.Flags().StringP("to", "t", "", "status to move to instead of the one before Blocked")
+	unblockCmd.Flags().StringP("comment", "m", "", "comment to add (defaults to a note listing the removed blockers)")
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/danielyan21/JiraCLI/internal/api"
 	"github.com/danielyan21/JiraCLI/internal/config"
 	"github.com/danielyan21/JiraCLI/internal/ui"
@@ -16,27 +14,36 @@ var viewCmd = &cobra.Command{
 
 Examples:
   jira view PROJ-123        # View full ticket details
-  jira view PROJ-123 -c     # View ticket with comments`,
-	Args: cobra.ExactArgs(1),
+  jira view PROJ-123 -c     # View ticket with comments
+  jira view PROJ-123 -f     # Also list custom fields (Story Points, Team, ...)
+  jira view                 # The ticket named by the current git branch`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ticketKey := args[0]
-		showComments, _ := cmd.Flags().GetBool("comments")
-
+		ctx := cmd.Context()
 		cfg := config.LoadAndValidate()
-
 		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 1, false)
+		ticketKey := args[0]
+		showComments, _ := cmd.Flags().GetBool("comments")
+		showFull, _ := cmd.Flags().GetBool("full")
 
-		fmt.Printf("Fetching details for %s...\n\n", ticketKey)
-		issue, err := client.GetIssue(ticketKey)
+		ui.Progress("Fetching details for %s...\n\n", ticketKey)
+		issue, err := client.GetIssueContext(ctx, ticketKey)
 		ui.FatalIfError(err, "Error fetching ticket")
 
 		var comments []api.Comment
 		if showComments {
-			comments, err = client.GetComments(ticketKey)
+			comments, err = client.GetCommentsContext(ctx, ticketKey)
 			ui.FatalIfError(err, "Error fetching comments")
 		}
 
-		ui.RenderIssueDetail(issue, cfg.JiraURL, comments)
+		var fieldMeta []api.Field
+		if showFull {
+			fieldMeta, err = client.GetFieldsContext(ctx)
+			ui.FatalIfError(err, "Error fetching field metadata")
+		}
+
+		ui.RenderIssueDetail(issue, cfg.JiraURL, comments, fieldMeta)
 	},
 }
 
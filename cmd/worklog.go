@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/config"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var worklogCmd = &cobra.Command{
+	Use:   "worklog",
+	Short: "List, edit and delete the time logged on a ticket",
+	Long: `List, edit and delete the time logged on a Jira ticket.
+
+Use "jira log" to log new time.
+
+Examples:
+  jira worklog list PROJ-123
+  jira worklog edit PROJ-123 10042 --time 2h -m "Pairing on the fix"
+  jira worklog delete PROJ-123 10042 --leave-estimate`,
+}
+
+var worklogListCmd = &cobra.Command{
+	Use:   "list [ticket-key]",
+	Short: "List the time logged on a ticket",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 1, false)
+		ticketKey := args[0]
+
+		ui.Progress("Fetching worklogs for %s...\n", ticketKey)
+		worklogs, err := client.GetWorklogsContext(ctx, ticketKey)
+		ui.FatalIfError(err, "Error fetching worklogs")
+
+		ui.RenderWorklogs(ticketKey, worklogs)
+	},
+}
+
+var worklogEditCmd = &cobra.Command{
+	Use:   "edit [ticket-key] [worklog-id]",
+	Short: "Change the time, start or comment of a worklog",
+	Args:  ticketKeyArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 2, false)
+		ticketKey, worklogID := args[0], args[1]
+		flags := cmd.Flags()
+
+		opts := api.WorklogOptions{}
+		opts.TimeSpent, _ = flags.GetString("time")
+		opts.Comment, _ = flags.GetString("comment")
+
+		var err error
+		if started, _ := flags.GetString("started"); started != "" {
+			opts.Started, err = parseStarted(started, time.Now())
+			ui.FatalIfError(err, "Invalid --started")
+		}
+		opts.Estimate, err = estimateFromFlags(flags, "")
+		ui.FatalIfError(err, "Invalid flags")
+
+		ui.Progress("Updating worklog %s on %s...\n", worklogID, ticketKey)
+		worklog, err := client.UpdateWorklogContext(ctx, ticketKey, worklogID, opts)
+		ui.FatalIfError(err, "Error updating worklog")
+
+		doc := ui.NewWorklogJSON(worklog)
+		ui.Result(ui.ActionJSON{Key: ticketKey, Action: "worklog-edit", Worklog: &doc},
+			"Updated worklog %s on %s (%s from %s)\n", worklog.ID, ticketKey,
+			ui.FormatDuration(worklog.TimeSpentSeconds), worklog.Started.Local().Format("2006-01-02 15:04"))
+	},
+}
+
+var worklogDeleteCmd = &cobra.Command{
+	Use:   "delete [ticket-key] [worklog-id]",
+	Short: "Delete a worklog",
+	Args:  ticketKeyArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := config.LoadAndValidate()
+		client := cfg.NewAPIClient()
+		args = ticketArgs(ctx, cfg, client, args, 2, false)
+		ticketKey, worklogID := args[0], args[1]
+
+		estimate, err := estimateFromFlags(cmd.Flags(), "increase-by")
+		ui.FatalIfError(err, "Invalid flags")
+
+		ui.Progress("Deleting worklog %s from %s...\n", worklogID, ticketKey)
+		err = client.DeleteWorklogContext(ctx, ticketKey, worklogID, estimate)
+		ui.FatalIfError(err, "Error deleting worklog")
+
+		ui.Result(ui.ActionJSON{Key: ticketKey, Action: "worklog-delete", Target: worklogID},
+			"Deleted worklog %s from %s\n", worklogID, ticketKey)
+	},
+}
+
+// estimateFromFlags reads the remaining-estimate flags shared by the
+// worklog commands. manualFlag names the command's manual adjustment flag
+// (--reduce-by or --increase-by), or is empty if it has none.
+func estimateFromFlags(flags *pflag.FlagSet, manualFlag string) (api.EstimateAdjustment, error) {
+	var estimate api.EstimateAdjustment
+	var set []string
+
+	if flags.Changed("new-estimate") {
+		estimate.Mode = api.EstimateNew
+		estimate.Amount, _ = flags.GetString("new-estimate")
+		set = append(set, "--new-estimate")
+	}
+	if manualFlag != "" && flags.Changed(manualFlag) {
+		estimate.Mode = api.EstimateManual
+		estimate.Amount, _ = flags.GetString(manualFlag)
+		set = append(set, "--"+manualFlag)
+	}
+	if leave, _ := flags.GetBool("leave-estimate"); leave {
+		estimate.Mode = api.EstimateLeave
+		set = append(set, "--leave-estimate")
+	}
+
+	if len(set) > 1 {
+		return api.EstimateAdjustment{}, fmt.Errorf("%s can't be used together", strings.Join(set, " and "))
+	}
+	return estimate, nil
+}
+
+func addEstimateFlags(cmd *cobra.Command, manualFlag, manualUsage string) {
+	cmd.Flags().String("new-estimate", "", "Set the remaining estimate, e.g. 3h")
+	if manualFlag != "" {
+		cmd.Flags().String(manualFlag, "", manualUsage)
+	}
+	cmd.Flags().Bool("leave-estimate", false, "Leave the remaining estimate unchanged")
+}
+
+// startedLayouts are the --started formats, tried in order; the ones
+// without a zone are in local time.
+var startedLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseStarted reads a --started value: a timestamp, a date (meaning 9am
+// that day), a time of day today, or "yesterday" optionally followed by a
+// time.
+func parseStarted(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if rest, ok := strings.CutPrefix(strings.ToLower(value), "yesterday"); ok {
+		day := now.AddDate(0, 0, -1)
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			return day, nil
+		}
+		clock, err := time.ParseInLocation("15:04", rest, now.Location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: use HH:MM", rest)
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location()), nil
+	}
+
+	if clock, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location()), nil
+	}
+
+	for _, layout := range startedLayouts {
+		t, err := time.ParseInLocation(layout, value, now.Location())
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" {
+			t = t.Add(9 * time.Hour)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid start %q: use YYYY-MM-DD, \"YYYY-MM-DD HH:MM\", HH:MM or an RFC 3339 timestamp", value)
+}
+
+func init() {
+	rootCmd.AddCommand(worklogCmd)
+	worklogCmd.AddCommand(worklogListCmd, worklogEditCmd, worklogDeleteCmd)
+
+	worklogEditCmd.Flags().StringP("time", "t", "", "New time spent, e.g. 1h30m")
+	worklogEditCmd.Flags().String("started", "", "New start time, e.g. \"2025-06-02 14:00\" or 14:00")
+	worklogEditCmd.Flags().StringP("comment", "m", "", "New comment")
+	addEstimateFlags(worklogEditCmd, "", "")
+
+	addEstimateFlags(worklogDeleteCmd, "increase-by", "Increase the remaining estimate by this much, e.g. 1h")
+}
@@ -0,0 +1,83 @@
+// Package adf converts Markdown typed on the command line into the formats
+// Jira stores rich text in: Atlassian Document Format (ADF) for REST API v3
+// (Jira Cloud) and wiki markup for API v2 (Jira Server/DC).
+package adf
+
+// Node is one element of an ADF document. The same type describes block
+// nodes (doc, paragraph, bulletList, ...) and inline nodes (text, mention,
+// hardBreak, ...); unused fields are omitted when marshaled.
+type Node struct {
+	Type    string                 `json:"type"`
+	Version int                    `json:"version,omitempty"` // doc only
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Content []*Node                `json:"content,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Marks   []Mark                 `json:"marks,omitempty"`
+}
+
+// Mark is inline formatting applied to a text node: strong, em, code,
+// strike or link.
+type Mark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// Node and mark type names used by the converter.
+const (
+	TypeDoc         = "doc"
+	TypeParagraph   = "paragraph"
+	TypeText        = "text"
+	TypeHardBreak   = "hardBreak"
+	TypeHeading     = "heading"
+	TypeBulletList  = "bulletList"
+	TypeOrderedList = "orderedList"
+	TypeListItem    = "listItem"
+	TypeCodeBlock   = "codeBlock"
+	TypeBlockquote  = "blockquote"
+	TypeRule        = "rule"
+	TypeMention     = "mention"
+	TypeTable       = "table"
+	TypeTableRow    = "tableRow"
+	TypeTableHeader = "tableHeader"
+	TypeTableCell   = "tableCell"
+	TypePanel       = "panel"
+
+	// Nodes Jira produces that the Markdown converter never emits.
+	TypeEmoji        = "emoji"
+	TypeInlineCard   = "inlineCard"
+	TypeBlockCard    = "blockCard"
+	TypeEmbedCard    = "embedCard"
+	TypeStatus       = "status"
+	TypeDate         = "date"
+	TypeExpand       = "expand"
+	TypeNestedExpand = "nestedExpand"
+	TypeTaskList     = "taskList"
+	TypeTaskItem     = "taskItem"
+	TypeDecisionList = "decisionList"
+	TypeDecisionItem = "decisionItem"
+	TypeMediaSingle  = "mediaSingle"
+	TypeMediaGroup   = "mediaGroup"
+	TypeMedia        = "media"
+
+	MarkStrong    = "strong"
+	MarkEm        = "em"
+	MarkCode      = "code"
+	MarkStrike    = "strike"
+	MarkLink      = "link"
+	MarkUnderline = "underline"
+	MarkSubSup    = "subsup"
+)
+
+// Doc wraps block nodes in an ADF document.
+func Doc(content ...*Node) *Node {
+	if content == nil {
+		content = []*Node{}
+	}
+	return &Node{Type: TypeDoc, Version: 1, Content: content}
+}
+
+// Paragraph returns a paragraph holding plain text, with newlines kept as
+// hard breaks.
+func Paragraph(text string) *Node {
+	return &Node{Type: TypeParagraph, Content: textWithBreaks(text, nil)}
+}
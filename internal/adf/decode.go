@@ -0,0 +1,58 @@
+package adf
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Decode converts an ADF value as decoded by encoding/json into
+// interface{} (the shape api.Issue and api.Comment hold) into a Node tree.
+func Decode(v interface{}) (*Node, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding ADF: %w", err)
+	}
+	var node Node
+	if err := json.Unmarshal(raw, &node); err != nil {
+		return nil, fmt.Errorf("decoding ADF: %w", err)
+	}
+	return &node, nil
+}
+
+// FromBody converts a rich-text value as Jira returns it, a wiki markup
+// string (API v2) or a decoded ADF document (API v3), into a Node tree.
+func FromBody(body interface{}) (*Node, error) {
+	switch b := body.(type) {
+	case nil:
+		return Doc(), nil
+	case string:
+		return FromWiki(b), nil
+	case *Node:
+		return b, nil
+	}
+	return Decode(body)
+}
+
+// AttrString returns a string attribute, or "" if it is missing.
+func (n *Node) AttrString(key string) string {
+	s, _ := n.Attrs[key].(string)
+	return s
+}
+
+// AttrInt returns a numeric attribute. Attributes decoded from JSON are
+// float64, while ones built in Go are int; both are accepted.
+func (n *Node) AttrInt(key string) (int, bool) {
+	switch v := n.Attrs[key].(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// MarkAttrString returns a string attribute of the mark.
+func (m Mark) AttrString(key string) string {
+	s, _ := m.Attrs[key].(string)
+	return s
+}
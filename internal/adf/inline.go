@@ -0,0 +1,259 @@
+package adf
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// parseInline converts inline Markdown into text, mention and hardBreak
+// nodes, applying marks on top of the ones inherited from enclosing spans.
+func parseInline(s string, marks []Mark) []*Node {
+	var nodes []*Node
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, textWithBreaks(text.String(), marks)...)
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_~[]()<>#|!-+.", rune(rest[1])):
+			text.WriteByte(rest[1])
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			ticks := len(rest) - len(strings.TrimLeft(rest, "`"))
+			fence := rest[:ticks]
+			if end := strings.Index(rest[ticks:], fence); end > 0 {
+				flush()
+				code := strings.TrimSpace(rest[ticks : ticks+end])
+				// ADF only allows link alongside code, so drop other marks.
+				nodes = append(nodes, &Node{Type: TypeText, Text: code, Marks: codeMarks(marks)})
+				i += ticks + end + ticks
+				continue
+			}
+
+		case strings.HasPrefix(rest, "[~"):
+			if end := strings.IndexByte(rest, ']'); end > 2 {
+				flush()
+				nodes = append(nodes, mentionNode(rest[2:end]))
+				i += end + 1
+				continue
+			}
+
+		case rest[0] == '[':
+			if label, href, n, ok := parseLink(rest); ok {
+				flush()
+				nodes = append(nodes, parseInline(label, withMark(marks, linkMark(href)))...)
+				i += n
+				continue
+			}
+
+		case rest[0] == '<':
+			if end := strings.IndexByte(rest, '>'); end > 0 && isURL(rest[1:end]) {
+				flush()
+				href := rest[1:end]
+				nodes = append(nodes, &Node{Type: TypeText, Text: href, Marks: withMark(marks, linkMark(href))})
+				i += end + 1
+				continue
+			}
+
+		case (strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://")) && !hasMark(marks, MarkLink):
+			href := bareURL(rest)
+			flush()
+			nodes = append(nodes, &Node{Type: TypeText, Text: href, Marks: withMark(marks, linkMark(href))})
+			i += len(href)
+			continue
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if inner, n, ok := delimited(s, i, rest[:2]); ok {
+				flush()
+				nodes = append(nodes, parseInline(inner, withMark(marks, Mark{Type: MarkStrong}))...)
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if inner, n, ok := delimited(s, i, "~~"); ok {
+				flush()
+				nodes = append(nodes, parseInline(inner, withMark(marks, Mark{Type: MarkStrike}))...)
+				i += n
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_':
+			if inner, n, ok := delimited(s, i, rest[:1]); ok {
+				flush()
+				nodes = append(nodes, parseInline(inner, withMark(marks, Mark{Type: MarkEm}))...)
+				i += n
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		text.WriteRune(r)
+		i += size
+	}
+
+	flush()
+	return nodes
+}
+
+// delimited finds the span opened by delim at s[i:]. Like CommonMark, the
+// opener must be followed by non-space and the closer preceded by non-space,
+// and underscores must not sit inside a word (so snake_case stays literal).
+func delimited(s string, i int, delim string) (inner string, n int, ok bool) {
+	start := i + len(delim)
+	if start >= len(s) || s[start] == ' ' || s[start] == '\n' {
+		return "", 0, false
+	}
+	if delim[0] == '_' && i > 0 && isWordByte(s[i-1]) {
+		return "", 0, false
+	}
+
+	for search := start; ; {
+		end := strings.Index(s[search:], delim)
+		if end < 0 {
+			return "", 0, false
+		}
+		end += search
+		after := end + len(delim)
+
+		valid := end > start && s[end-1] != ' ' && s[end-1] != '\n'
+		// A single * or _ must not be half of a ** or __.
+		if len(delim) == 1 && after < len(s) && s[after] == delim[0] {
+			valid = false
+		}
+		if delim[0] == '_' && after < len(s) && isWordByte(s[after]) {
+			valid = false
+		}
+		if valid {
+			return s[start:end], after - i, true
+		}
+		search = end + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 0x80 || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
+
+// parseLink parses [label](href) at the start of s, returning how many bytes
+// it spans.
+func parseLink(s string) (label, href string, n int, ok bool) {
+	depth := 0
+	closeBracket := -1
+	for i := 0; i < len(s) && closeBracket < 0; i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeBracket = i
+			}
+		}
+	}
+	if closeBracket < 0 || closeBracket+1 >= len(s) || s[closeBracket+1] != '(' {
+		return "", "", 0, false
+	}
+	closeParen := strings.IndexByte(s[closeBracket:], ')')
+	if closeParen < 0 {
+		return "", "", 0, false
+	}
+	closeParen += closeBracket
+
+	href = strings.TrimSpace(s[closeBracket+2 : closeParen])
+	// Drop an optional "title".
+	if sp := strings.IndexAny(href, " \t"); sp >= 0 {
+		href = href[:sp]
+	}
+	if href == "" {
+		return "", "", 0, false
+	}
+	return s[1:closeBracket], href, closeParen + 1, true
+}
+
+func isURL(s string) bool {
+	return (strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") ||
+		strings.HasPrefix(s, "mailto:")) && !strings.ContainsAny(s, " \n")
+}
+
+// bareURL returns the URL at the start of s, leaving trailing punctuation
+// such as a sentence's closing period outside the link.
+func bareURL(s string) string {
+	end := strings.IndexAny(s, " \n\t<>\"")
+	if end < 0 {
+		end = len(s)
+	}
+	url := strings.TrimRight(s[:end], ".,;:!?'")
+	if strings.HasSuffix(url, ")") && !strings.Contains(url, "(") {
+		url = strings.TrimSuffix(url, ")")
+	}
+	return url
+}
+
+// mentionNode builds a mention from the inside of [~...]. Cloud mentions are
+// written [~accountid:ID]; anything else is taken to be the account ID too.
+func mentionNode(ref string) *Node {
+	id := strings.TrimPrefix(ref, "accountid:")
+	return &Node{
+		Type:  TypeMention,
+		Attrs: map[string]interface{}{"id": id, "text": "@" + id},
+	}
+}
+
+// textWithBreaks splits text on newlines into text nodes joined by hardBreaks.
+func textWithBreaks(text string, marks []Mark) []*Node {
+	var nodes []*Node
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			nodes = append(nodes, &Node{Type: TypeHardBreak})
+		}
+		if line != "" {
+			nodes = append(nodes, &Node{Type: TypeText, Text: line, Marks: marks})
+		}
+	}
+	return nodes
+}
+
+func linkMark(href string) Mark {
+	return Mark{Type: MarkLink, Attrs: map[string]interface{}{"href": href}}
+}
+
+// withMark returns a copy of marks with mark added, so sibling spans don't
+// share a backing array.
+func withMark(marks []Mark, mark Mark) []Mark {
+	if hasMark(marks, mark.Type) {
+		return marks
+	}
+	result := make([]Mark, 0, len(marks)+1)
+	result = append(result, marks...)
+	return append(result, mark)
+}
+
+func hasMark(marks []Mark, markType string) bool {
+	for _, m := range marks {
+		if m.Type == markType {
+			return true
+		}
+	}
+	return false
+}
+
+func codeMarks(marks []Mark) []Mark {
+	result := []Mark{{Type: MarkCode}}
+	for _, m := range marks {
+		if m.Type == MarkLink {
+			result = append(result, m)
+		}
+	}
+	return result
+}
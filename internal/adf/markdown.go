@@ -0,0 +1,323 @@
+package adf
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FromMarkdown converts Markdown into an ADF document. It understands the
+// subset people type into a terminal: ATX headings, bullet and ordered lists
+// (nested by indentation), fenced code blocks, block quotes, horizontal rules,
+// pipe tables, and inline bold, italic, strikethrough, code, links and
+// mentions. Mentions use Jira's own syntax, [~accountid:ID]. Single newlines
+// inside a paragraph are kept as line breaks.
+func FromMarkdown(markdown string) *Node {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	return Doc(parseBlocks(strings.Split(markdown, "\n"))...)
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	fencePattern    = regexp.MustCompile("^( {0,3})(```+|~~~+)\\s*([^`\\s]*)")
+	listPattern     = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])(?:\s+(.*))?$`)
+	tableSepPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+func parseBlocks(lines []string) []*Node {
+	var blocks []*Node
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case fencePattern.MatchString(line):
+			var block *Node
+			block, i = parseCodeBlock(lines, i)
+			blocks = append(blocks, block)
+
+		case headingPattern.MatchString(line):
+			m := headingPattern.FindStringSubmatch(line)
+			blocks = append(blocks, &Node{
+				Type:    TypeHeading,
+				Attrs:   map[string]interface{}{"level": len(m[1])},
+				Content: parseInline(m[2], nil),
+			})
+			i++
+
+		case isRule(trimmed):
+			blocks = append(blocks, &Node{Type: TypeRule})
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quoted = append(quoted, stripQuote(lines[i]))
+			}
+			blocks = append(blocks, &Node{Type: TypeBlockquote, Content: nonEmpty(parseBlocks(quoted))})
+
+		case isTableStart(lines, i):
+			var table *Node
+			table, i = parseTable(lines, i)
+			blocks = append(blocks, table)
+
+		case listPattern.MatchString(line):
+			var list *Node
+			list, i = parseList(lines, i)
+			blocks = append(blocks, list)
+
+		default:
+			var paragraph []string
+			for ; i < len(lines); i++ {
+				if len(paragraph) > 0 && startsBlock(lines, i) {
+					break
+				}
+				if strings.TrimSpace(lines[i]) == "" {
+					break
+				}
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			blocks = append(blocks, &Node{
+				Type:    TypeParagraph,
+				Content: parseInline(strings.Join(paragraph, "\n"), nil),
+			})
+		}
+	}
+
+	return blocks
+}
+
+// startsBlock reports whether line i interrupts a running paragraph.
+func startsBlock(lines []string, i int) bool {
+	line := lines[i]
+	trimmed := strings.TrimSpace(line)
+	return fencePattern.MatchString(line) ||
+		headingPattern.MatchString(line) ||
+		isRule(trimmed) ||
+		strings.HasPrefix(trimmed, ">") ||
+		isTableStart(lines, i) ||
+		listPattern.MatchString(line)
+}
+
+// isRule matches thematic breaks: three or more of the same -, * or _,
+// optionally separated by spaces.
+func isRule(trimmed string) bool {
+	compact := strings.ReplaceAll(trimmed, " ", "")
+	if len(compact) < 3 {
+		return false
+	}
+	c := compact[0]
+	if c != '-' && c != '*' && c != '_' {
+		return false
+	}
+	return strings.Count(compact, string(c)) == len(compact)
+}
+
+// stripQuote removes a line's > marker and any whitespace before it.
+func stripQuote(line string) string {
+	line = strings.TrimLeftFunc(line, unicode.IsSpace)
+	line = strings.TrimPrefix(line, ">")
+	return strings.TrimPrefix(line, " ")
+}
+
+func parseCodeBlock(lines []string, start int) (*Node, int) {
+	m := fencePattern.FindStringSubmatch(lines[start])
+	indent, fence, language := len(m[1]), m[2], m[3]
+
+	var code []string
+	i := start + 1
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) &&
+			strings.Trim(strings.TrimSpace(lines[i]), fence[:1]) == "" {
+			i++
+			break
+		}
+		// Drop the fence's own indentation from each code line.
+		line := lines[i]
+		for n := 0; n < indent && strings.HasPrefix(line, " "); n++ {
+			line = line[1:]
+		}
+		code = append(code, line)
+	}
+
+	block := &Node{Type: TypeCodeBlock}
+	if language != "" {
+		block.Attrs = map[string]interface{}{"language": language}
+	}
+	if text := strings.Join(code, "\n"); text != "" {
+		block.Content = []*Node{{Type: TypeText, Text: text}}
+	}
+	return block, i
+}
+
+func isTableStart(lines []string, i int) bool {
+	return strings.HasPrefix(strings.TrimSpace(lines[i]), "|") &&
+		i+1 < len(lines) &&
+		strings.Contains(lines[i+1], "-") &&
+		tableSepPattern.MatchString(lines[i+1])
+}
+
+func parseTable(lines []string, start int) (*Node, int) {
+	table := &Node{
+		Type:  TypeTable,
+		Attrs: map[string]interface{}{"isNumberColumnEnabled": false, "layout": "default"},
+	}
+	table.Content = append(table.Content, tableRow(lines[start], TypeTableHeader))
+
+	i := start + 2
+	for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+		table.Content = append(table.Content, tableRow(lines[i], TypeTableCell))
+	}
+	return table, i
+}
+
+func tableRow(line, cellType string) *Node {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+
+	row := &Node{Type: TypeTableRow}
+	for _, cell := range splitTableCells(line) {
+		paragraph := &Node{Type: TypeParagraph, Content: parseInline(strings.TrimSpace(cell), nil)}
+		row.Content = append(row.Content, &Node{
+			Type:    cellType,
+			Attrs:   map[string]interface{}{},
+			Content: []*Node{paragraph},
+		})
+	}
+	return row
+}
+
+// splitTableCells splits on pipes that aren't escaped or inside code spans.
+func splitTableCells(line string) []string {
+	var cells []string
+	var current strings.Builder
+	inCode := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			current.WriteByte('|')
+			i++
+		case line[i] == '`':
+			inCode = !inCode
+			current.WriteByte('`')
+		case line[i] == '|' && !inCode:
+			cells = append(cells, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(line[i])
+		}
+	}
+	return append(cells, current.String())
+}
+
+// parseList consumes a run of list items of the same kind at the same
+// indentation. Each item's lines (its first line plus anything indented
+// under it) are parsed recursively, which is what makes nesting work.
+func parseList(lines []string, start int) (*Node, int) {
+	first := listPattern.FindStringSubmatch(lines[start])
+	indent := len(first[1])
+	ordered := isOrderedMarker(first[2])
+
+	list := &Node{Type: TypeBulletList}
+	if ordered {
+		list.Type = TypeOrderedList
+		order, _ := strconv.Atoi(strings.TrimRight(first[2], ".)"))
+		if order != 1 {
+			list.Attrs = map[string]interface{}{"order": order}
+		}
+	}
+
+	i := start
+	for i < len(lines) {
+		m := listPattern.FindStringSubmatch(lines[i])
+		if m == nil || len(m[1]) != indent || isOrderedMarker(m[2]) != ordered {
+			break
+		}
+
+		contentIndent := indent + len(m[2]) + 1
+		itemLines := []string{m[3]}
+		i++
+
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				// A blank line only continues the item if more indented
+				// content follows it.
+				next := i + 1
+				for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+					next++
+				}
+				if next < len(lines) && leadingSpaces(lines[next]) > indent {
+					itemLines = append(itemLines, "")
+					i++
+					continue
+				}
+				break
+			}
+
+			if leadingSpaces(line) > indent {
+				itemLines = append(itemLines, dedent(line, contentIndent))
+				i++
+				continue
+			}
+
+			// Lazy continuation: an unindented line that doesn't start a new
+			// block still belongs to the item's text.
+			if !startsBlock(lines, i) {
+				itemLines = append(itemLines, strings.TrimSpace(line))
+				i++
+				continue
+			}
+			break
+		}
+
+		item := &Node{Type: TypeListItem, Content: parseBlocks(itemLines)}
+		if len(item.Content) == 0 {
+			item.Content = []*Node{{Type: TypeParagraph}}
+		}
+		list.Content = append(list.Content, item)
+
+		// Skip blank lines between items of the same list.
+		next := i
+		for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+			next++
+		}
+		if next > i && next < len(lines) {
+			if m := listPattern.FindStringSubmatch(lines[next]); m != nil && len(m[1]) == indent {
+				i = next
+			}
+		}
+	}
+
+	return list, i
+}
+
+func isOrderedMarker(marker string) bool {
+	return marker[0] >= '0' && marker[0] <= '9'
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// dedent removes up to n leading spaces.
+func dedent(line string, n int) string {
+	spaces := leadingSpaces(line)
+	if spaces > n {
+		spaces = n
+	}
+	return line[spaces:]
+}
+
+func nonEmpty(blocks []*Node) []*Node {
+	if len(blocks) == 0 {
+		return []*Node{{Type: TypeParagraph}}
+	}
+	return blocks
+}
@@ -0,0 +1,28 @@
+package adf
+
+import "testing"
+
+func TestFromMarkdownQuotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"plain", "> quoted", "quoted"},
+		{"indented", "   > quoted", "quoted"},
+		{"tab indented", "\t> quoted", "quoted"},
+		{"tab after marker", ">\tquoted", "quoted"},
+		{"nested", "\t> \t> deeper", "deeper"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := FromMarkdown(tt.markdown)
+			if len(doc.Content) != 1 || doc.Content[0].Type != TypeBlockquote {
+				t.Fatalf("FromMarkdown(%q) = %+v, want one quote", tt.markdown, doc.Content)
+			}
+			if got := plainText(doc); got != tt.want {
+				t.Errorf("quote text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
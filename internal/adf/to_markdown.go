@@ -0,0 +1,349 @@
+package adf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ToMarkdown renders an ADF document as the Markdown dialect FromMarkdown
+// reads, so rich text can be edited in a text editor and sent back. Nodes
+// Markdown has no syntax for (panels, expands, media) degrade to the
+// closest equivalent.
+func ToMarkdown(doc *Node) string {
+	return strings.Join(markdownBlocks(doc.Content), "\n\n")
+}
+
+// lossyNodes names what ToMarkdown can only approximate, so a document
+// holding them doesn't survive ToMarkdown and FromMarkdown unchanged.
+var lossyNodes = map[string]string{
+	TypePanel:        "panels",
+	TypeExpand:       "expands",
+	TypeNestedExpand: "expands",
+	TypeMediaSingle:  "images or attachments",
+	TypeMediaGroup:   "images or attachments",
+	TypeMedia:        "images or attachments",
+	TypeBlockCard:    "link cards",
+	TypeEmbedCard:    "link cards",
+	TypeStatus:       "status lozenges",
+	TypeDate:         "dates",
+}
+
+var lossyMarks = map[string]string{
+	MarkUnderline: "underlined text",
+	MarkSubSup:    "subscripts or superscripts",
+}
+
+// MarkdownLosses lists what doc holds that ToMarkdown can only
+// approximate, e.g. "panels" or "images or attachments", each once and in
+// document order. Sending the Markdown back would replace those with text.
+func MarkdownLosses(doc *Node) []string {
+	seen := map[string]bool{}
+	var losses []string
+	add := func(what string) {
+		if what != "" && !seen[what] {
+			seen[what] = true
+			losses = append(losses, what)
+		}
+	}
+	var walk func(node *Node)
+	walk = func(node *Node) {
+		add(lossyNodes[node.Type])
+		for _, mark := range node.Marks {
+			add(lossyMarks[mark.Type])
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(doc)
+	return losses
+}
+
+func markdownBlocks(nodes []*Node) []string {
+	var blocks []string
+	for _, node := range nodes {
+		if rendered := markdownBlock(node); rendered != "" {
+			blocks = append(blocks, rendered)
+		}
+	}
+	return blocks
+}
+
+func markdownBlock(node *Node) string {
+	switch node.Type {
+	case TypeParagraph:
+		return escapeLineStarts(markdownInline(node.Content))
+
+	case TypeHeading:
+		level, _ := node.AttrInt("level")
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return strings.Repeat("#", level) + " " + strings.ReplaceAll(markdownInline(node.Content), "\n", " ")
+
+	case TypeBulletList, TypeOrderedList, TypeTaskList, TypeDecisionList:
+		return markdownList(node)
+
+	case TypeCodeBlock:
+		fence := "```"
+		code := plainText(node)
+		if strings.Contains(code, "```") {
+			fence = "~~~"
+		}
+		return fence + node.AttrString("language") + "\n" + code + "\n" + fence
+
+	case TypeBlockquote, TypePanel:
+		return prefixLines(strings.Join(markdownBlocks(node.Content), "\n\n"), "> ", ">")
+
+	case TypeRule:
+		return "---"
+
+	case TypeTable:
+		return markdownTable(node)
+
+	case TypeExpand, TypeNestedExpand:
+		blocks := markdownBlocks(node.Content)
+		if title := node.AttrString("title"); title != "" {
+			blocks = append([]string{"**" + title + "**"}, blocks...)
+		}
+		return strings.Join(blocks, "\n\n")
+
+	case TypeMediaSingle, TypeMediaGroup:
+		var names []string
+		for _, media := range node.Content {
+			name := media.AttrString("alt")
+			if name == "" {
+				name = media.AttrString("id")
+			}
+			names = append(names, "[attachment: "+name+"]")
+		}
+		return strings.Join(names, "\n")
+
+	case TypeBlockCard, TypeEmbedCard:
+		return node.AttrString("url")
+	}
+
+	if len(node.Content) > 0 && node.Content[0].Type != TypeText {
+		return strings.Join(markdownBlocks(node.Content), "\n\n")
+	}
+	return markdownInline(node.Content)
+}
+
+// markdownList renders list items with their content indented under the
+// marker, so nested lists and continuation lines stay inside the item.
+func markdownList(node *Node) string {
+	order := 1
+	if n, ok := node.AttrInt("order"); ok {
+		order = n
+	}
+
+	var lines []string
+	for i, item := range node.Content {
+		var marker string
+		switch node.Type {
+		case TypeOrderedList:
+			marker = strconv.Itoa(order+i) + ". "
+		case TypeTaskList:
+			marker = "- [ ] "
+			if item.AttrString("state") == "DONE" {
+				marker = "- [x] "
+			}
+		default:
+			marker = "- "
+		}
+
+		var body strings.Builder
+		for j, child := range item.Content {
+			rendered := markdownBlock(child)
+			if j > 0 {
+				if isListNode(child) {
+					body.WriteString("\n")
+				} else {
+					body.WriteString("\n\n")
+				}
+			}
+			body.WriteString(rendered)
+		}
+		if item.Type != TypeListItem && len(item.Content) > 0 && item.Content[0].Type == TypeText {
+			body.Reset()
+			body.WriteString(markdownInline(item.Content))
+		}
+
+		indent := strings.Repeat(" ", len(marker))
+		if node.Type == TypeTaskList {
+			indent = "  "
+		}
+		text := prefixLines(body.String(), indent, "")
+		lines = append(lines, marker+strings.TrimPrefix(text, indent))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isListNode(node *Node) bool {
+	switch node.Type {
+	case TypeBulletList, TypeOrderedList, TypeTaskList, TypeDecisionList:
+		return true
+	}
+	return false
+}
+
+func markdownTable(node *Node) string {
+	var rows []string
+	for i, row := range node.Content {
+		cells := make([]string, len(row.Content))
+		for j, cell := range row.Content {
+			text := strings.Join(markdownBlocks(cell.Content), " ")
+			text = strings.ReplaceAll(text, "\n", " ")
+			cells[j] = strings.ReplaceAll(text, "|", `\|`)
+		}
+		rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			separators := make([]string, len(cells))
+			for j := range separators {
+				separators[j] = "---"
+			}
+			rows = append(rows, "| "+strings.Join(separators, " | ")+" |")
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// prefixLines prefixes every line of s, using emptyPrefix for blank lines.
+func prefixLines(s, prefix, emptyPrefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func markdownInline(nodes []*Node) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case TypeText:
+			b.WriteString(markdownText(node))
+		case TypeHardBreak:
+			b.WriteString("\n")
+		case TypeMention:
+			b.WriteString("[~" + node.AttrString("id") + "]")
+		case TypeEmoji:
+			if text := node.AttrString("text"); text != "" {
+				b.WriteString(text)
+			} else {
+				b.WriteString(node.AttrString("shortName"))
+			}
+		case TypeInlineCard:
+			b.WriteString(node.AttrString("url"))
+		case TypeStatus:
+			b.WriteString("[" + node.AttrString("text") + "]")
+		case TypeDate:
+			text := node.AttrString("timestamp")
+			if ms, err := strconv.ParseInt(text, 10, 64); err == nil {
+				text = time.UnixMilli(ms).UTC().Format("2006-01-02")
+			}
+			b.WriteString(text)
+		default:
+			b.WriteString(markdownInline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+func markdownText(node *Node) string {
+	if node.Text == "" {
+		return ""
+	}
+
+	// Emphasis can't open or close on whitespace, so keep the text's
+	// surrounding spaces outside the markers.
+	core := strings.TrimSpace(node.Text)
+	if core == "" {
+		return node.Text
+	}
+	lead := node.Text[:strings.Index(node.Text, core)]
+	trail := node.Text[len(lead)+len(core):]
+
+	var href string
+	text := escapeMarkdown(core)
+	if hasMark(node.Marks, MarkCode) {
+		fence := "`"
+		if strings.Contains(core, "`") {
+			fence = "`` "
+			text = fence + core + " ``"
+		} else {
+			text = fence + core + fence
+		}
+	}
+	for _, mark := range node.Marks {
+		switch mark.Type {
+		case MarkEm:
+			text = "*" + text + "*"
+		case MarkStrong:
+			text = "**" + text + "**"
+		case MarkStrike:
+			text = "~~" + text + "~~"
+		case MarkLink:
+			href = mark.AttrString("href")
+		}
+	}
+	if href != "" {
+		if core == href && len(node.Marks) == 1 {
+			text = href
+		} else {
+			text = fmt.Sprintf("[%s](%s)", text, href)
+		}
+	}
+	return lead + text + trail
+}
+
+// escapeMarkdown backslash-escapes characters FromMarkdown would read as
+// inline syntax. Underscores inside words (snake_case) are left alone.
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\\', '`', '*', '[', ']':
+			b.WriteByte('\\')
+		case '_':
+			if i == 0 || i == len(s)-1 || !isWordByte(s[i-1]) || !isWordByte(s[i+1]) {
+				b.WriteByte('\\')
+			}
+		case '~':
+			if i+1 < len(s) && s[i+1] == '~' {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// escapeLineStarts escapes paragraph lines that would otherwise start a
+// heading, list, quote or rule.
+func escapeLineStarts(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		switch {
+		case line[0] == '#' || line[0] == '>' || line[0] == '|':
+			lines[i] = `\` + line
+		case (line[0] == '-' || line[0] == '+') && (len(line) == 1 || line[1] == ' ' || isRule(line)):
+			lines[i] = `\` + line
+		case listPattern.MatchString(line):
+			m := listPattern.FindStringSubmatchIndex(line)
+			markerEnd := m[5]
+			lines[i] = line[:markerEnd-1] + `\` + line[markerEnd-1:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,39 @@
+package adf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarkdownLosses(t *testing.T) {
+	text := func(s string, marks ...string) *Node {
+		node := &Node{Type: TypeText, Text: s}
+		for _, mark := range marks {
+			node.Marks = append(node.Marks, Mark{Type: mark})
+		}
+		return node
+	}
+	para := func(content ...*Node) *Node { return &Node{Type: TypeParagraph, Content: content} }
+
+	tests := []struct {
+		name string
+		doc  *Node
+		want []string
+	}{
+		{"plain", Doc(Paragraph("hello")), nil},
+		{"markdown marks", Doc(para(text("a", MarkStrong), text("b", MarkEm, MarkCode), text("c", MarkStrike))), nil},
+		{"panel", Doc(&Node{Type: TypePanel, Content: []*Node{Paragraph("note")}}), []string{"panels"}},
+		{"image in a list", Doc(&Node{Type: TypeBulletList, Content: []*Node{
+			{Type: TypeListItem, Content: []*Node{{Type: TypeMediaSingle, Content: []*Node{{Type: TypeMedia}}}}},
+		}}), []string{"images or attachments"}},
+		{"inline nodes", Doc(para(&Node{Type: TypeStatus}, &Node{Type: TypeDate}, &Node{Type: TypeStatus})), []string{"status lozenges", "dates"}},
+		{"expand and underline", Doc(&Node{Type: TypeExpand, Content: []*Node{para(text("u", MarkUnderline))}}), []string{"expands", "underlined text"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownLosses(tt.doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MarkdownLosses() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package adf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarkdownToWiki converts Markdown into Jira wiki markup, the rich-text
+// format of REST API v2 (Jira Server/DC).
+func MarkdownToWiki(markdown string) string {
+	return ToWiki(FromMarkdown(markdown))
+}
+
+// ToWiki renders an ADF document as Jira wiki markup.
+func ToWiki(doc *Node) string {
+	var blocks []string
+	for _, block := range doc.Content {
+		if rendered := wikiBlock(block, ""); rendered != "" {
+			blocks = append(blocks, rendered)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// wikiBlock renders a block node. listPrefix is the run of * and # that
+// wiki markup uses for list nesting, e.g. "*#" for a numbered list inside
+// a bullet list.
+func wikiBlock(node *Node, listPrefix string) string {
+	switch node.Type {
+	case TypeParagraph:
+		return escapeWikiLineStarts(wikiInline(node.Content))
+
+	case TypeHeading:
+		level, _ := node.AttrInt("level")
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return fmt.Sprintf("h%d. %s", level, wikiInline(node.Content))
+
+	case TypeBulletList, TypeOrderedList:
+		marker := "*"
+		if node.Type == TypeOrderedList {
+			marker = "#"
+		}
+		var lines []string
+		for _, item := range node.Content {
+			lines = append(lines, wikiListItem(item, listPrefix+marker)...)
+		}
+		return strings.Join(lines, "\n")
+
+	case TypeCodeBlock:
+		open := "{code}"
+		if language := node.AttrString("language"); language != "" {
+			open = "{code:" + language + "}"
+		}
+		return open + "\n" + plainText(node) + "\n{code}"
+
+	case TypeBlockquote:
+		var parts []string
+		for _, child := range node.Content {
+			parts = append(parts, wikiBlock(child, ""))
+		}
+		return "{quote}\n" + strings.Join(parts, "\n\n") + "\n{quote}"
+
+	case TypeRule:
+		return "----"
+
+	case TypeTable:
+		var rows []string
+		for _, row := range node.Content {
+			var b strings.Builder
+			for _, cell := range row.Content {
+				sep := "|"
+				if cell.Type == TypeTableHeader {
+					sep = "||"
+				}
+				b.WriteString(sep)
+				var parts []string
+				for _, child := range cell.Content {
+					parts = append(parts, wikiBlock(child, ""))
+				}
+				// Cells can't span lines; a space-padded cell keeps || intact.
+				content := strings.ReplaceAll(strings.Join(parts, " "), "\n", " ")
+				if content == "" {
+					content = " "
+				}
+				b.WriteString(content)
+				if cell == row.Content[len(row.Content)-1] {
+					b.WriteString(sep)
+				}
+			}
+			rows = append(rows, b.String())
+		}
+		return strings.Join(rows, "\n")
+	}
+
+	return wikiInline(node.Content)
+}
+
+func wikiListItem(item *Node, prefix string) []string {
+	var lines []string
+	var text []string
+	for _, child := range item.Content {
+		switch child.Type {
+		case TypeBulletList, TypeOrderedList:
+			if len(text) > 0 {
+				lines = append(lines, prefix+" "+strings.Join(text, " "))
+				text = nil
+			}
+			lines = append(lines, wikiBlock(child, prefix))
+		default:
+			// List items are single lines in wiki markup; hard breaks
+			// become a literal \\ line break.
+			text = append(text, strings.ReplaceAll(wikiBlock(child, ""), "\n", " \\\\ "))
+		}
+	}
+	if len(text) > 0 || len(lines) == 0 {
+		lines = append([]string{prefix + " " + strings.Join(text, " ")}, lines...)
+	}
+	return lines
+}
+
+func wikiInline(nodes []*Node) string {
+	var b strings.Builder
+	for i := 0; i < len(nodes); {
+		node := nodes[i]
+		switch node.Type {
+		case TypeText:
+			// Neighbouring text nodes share their marks, so render them
+			// as one run: "**bold _it_**" is *bold _it_*, not *bold *_*it*_.
+			href := linkHref(node)
+			j := i + 1
+			for j < len(nodes) && nodes[j].Type == TypeText && linkHref(nodes[j]) == href {
+				j++
+			}
+			b.WriteString(wikiTextRun(nodes[i:j], href))
+			i = j
+			continue
+		case TypeHardBreak:
+			b.WriteString("\n")
+		case TypeMention:
+			id := node.AttrString("id")
+			b.WriteString("[~" + id + "]")
+		default:
+			b.WriteString(escapeWiki(plainText(node)))
+		}
+		i++
+	}
+	return b.String()
+}
+
+func linkHref(node *Node) string {
+	for _, mark := range node.Marks {
+		if mark.Type == MarkLink {
+			return mark.AttrString("href")
+		}
+	}
+	return ""
+}
+
+// wikiDelimiters lists the marks wiki markup can express, outermost first.
+var wikiDelimiters = []struct {
+	mark, open, close string
+}{
+	{MarkStrong, "*", "*"},
+	{MarkEm, "_", "_"},
+	{MarkUnderline, "+", "+"},
+	{MarkStrike, "-", "-"},
+	{MarkCode, "{{", "}}"},
+}
+
+// wikiTextRun renders a run of text nodes, opening each mark once for as
+// long as consecutive nodes carry it, and wraps the run in a link when
+// href is set.
+func wikiTextRun(run []*Node, href string) string {
+	var out string
+	var open []int
+
+	closeFrom := func(n int) {
+		// Delimiters can't close after whitespace; keep it outside.
+		core := strings.TrimRight(out, " ")
+		trail := out[len(core):]
+		for k := len(open) - 1; k >= n; k-- {
+			core += wikiDelimiters[open[k]].close
+		}
+		out = core + trail
+		open = open[:n]
+	}
+
+	for _, node := range run {
+		for n, k := range open {
+			if !hasMark(node.Marks, wikiDelimiters[k].mark) {
+				closeFrom(n)
+				break
+			}
+		}
+
+		text := escapeWiki(node.Text)
+		core := strings.TrimLeft(text, " ")
+		if core != "" {
+			out += text[:len(text)-len(core)]
+			text = core
+			for k, d := range wikiDelimiters {
+				if hasMark(node.Marks, d.mark) && !containsInt(open, k) {
+					out += d.open
+					open = append(open, k)
+				}
+			}
+		}
+		out += text
+	}
+	closeFrom(0)
+
+	if href == "" {
+		return out
+	}
+	if len(run) == 1 && len(run[0].Marks) == 1 && run[0].Text == href {
+		return "[" + href + "]"
+	}
+	return "[" + out + "|" + href + "]"
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeWiki backslash-escapes characters Jira would read as wiki markup:
+// brackets and braces always, and mark delimiters only where they could
+// open or close a mark, so "a-b" and "snake_case" stay readable.
+func escapeWiki(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '[', ']', '{', '}', '|':
+			b.WriteByte('\\')
+		case '!':
+			if i+1 < len(s) && s[i+1] != ' ' && s[i+1] != '\n' {
+				b.WriteByte('\\')
+			}
+		case '?':
+			if (i+1 < len(s) && s[i+1] == '?') || (i > 0 && s[i-1] == '?') {
+				b.WriteByte('\\')
+			}
+		case '*', '_', '-', '+', '^', '~':
+			opens := (i == 0 || !isWordByte(s[i-1])) && i+1 < len(s) && s[i+1] != ' ' && s[i+1] != '\n'
+			closes := i > 0 && s[i-1] != ' ' && s[i-1] != '\n' && (i+1 == len(s) || !isWordByte(s[i+1]))
+			if opens || closes {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// escapeWikiLineStarts escapes paragraph lines that would otherwise start a
+// heading, list, quote, table or rule.
+func escapeWikiLineStarts(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if wikiStartsBlock(line) {
+			trimmed := strings.TrimLeft(line, " ")
+			lines[i] = line[:len(line)-len(trimmed)] + `\` + trimmed
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// plainText concatenates the text inside node, keeping hard breaks.
+func plainText(node *Node) string {
+	if node.Type == TypeText {
+		return node.Text
+	}
+	if node.Type == TypeHardBreak {
+		return "\n"
+	}
+	var b strings.Builder
+	for _, child := range node.Content {
+		b.WriteString(plainText(child))
+	}
+	return b.String()
+}
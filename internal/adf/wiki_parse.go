@@ -0,0 +1,442 @@
+package adf
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// FromWiki parses Jira wiki markup (what API v2 returns for descriptions and
+// comments) into an ADF document, so both API versions can share one
+// renderer. It covers headings, lists, {code}/{noformat}, {quote} and bq.,
+// {panel} and friends, tables, rules and the common inline markup.
+func FromWiki(markup string) *Node {
+	markup = strings.ReplaceAll(markup, "\r\n", "\n")
+	return Doc(parseWikiBlocks(strings.Split(markup, "\n"))...)
+}
+
+var (
+	wikiHeadingPattern = regexp.MustCompile(`^\s*h([1-6])\.\s*(.*)$`)
+	wikiListPattern    = regexp.MustCompile(`^\s*([*#]+|-)\s+(.*)$`)
+	wikiMacroPattern   = regexp.MustCompile(`^\s*\{(code|noformat|quote|panel|info|note|tip|warning)(?::([^}]*))?\}(.*)$`)
+)
+
+// wikiPanelTypes maps wiki admonition macros onto ADF panel types.
+var wikiPanelTypes = map[string]string{
+	"panel":   "info",
+	"info":    "info",
+	"note":    "note",
+	"tip":     "success",
+	"warning": "warning",
+}
+
+func parseWikiBlocks(lines []string) []*Node {
+	var blocks []*Node
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case wikiMacroPattern.MatchString(line):
+			var block *Node
+			block, i = parseWikiMacro(lines, i)
+			blocks = append(blocks, block)
+
+		case wikiHeadingPattern.MatchString(line):
+			m := wikiHeadingPattern.FindStringSubmatch(line)
+			level := int(m[1][0] - '0')
+			blocks = append(blocks, &Node{
+				Type:    TypeHeading,
+				Attrs:   map[string]interface{}{"level": level},
+				Content: parseWikiInline(m[2], nil),
+			})
+			i++
+
+		case strings.HasPrefix(trimmed, "bq. "):
+			blocks = append(blocks, &Node{
+				Type:    TypeBlockquote,
+				Content: []*Node{{Type: TypeParagraph, Content: parseWikiInline(trimmed[4:], nil)}},
+			})
+			i++
+
+		case trimmed == "----":
+			blocks = append(blocks, &Node{Type: TypeRule})
+			i++
+
+		case strings.HasPrefix(trimmed, "|"):
+			var table *Node
+			table, i = parseWikiTable(lines, i)
+			blocks = append(blocks, table)
+
+		case wikiListPattern.MatchString(line):
+			var list *Node
+			list, i = parseWikiList(lines, i)
+			blocks = append(blocks, list)
+
+		default:
+			var paragraph []string
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if t == "" || (len(paragraph) > 0 && wikiStartsBlock(lines[i])) {
+					break
+				}
+				paragraph = append(paragraph, t)
+			}
+			blocks = append(blocks, &Node{
+				Type:    TypeParagraph,
+				Content: parseWikiInline(strings.Join(paragraph, "\n"), nil),
+			})
+		}
+	}
+
+	return blocks
+}
+
+func wikiStartsBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return wikiMacroPattern.MatchString(line) ||
+		wikiHeadingPattern.MatchString(line) ||
+		strings.HasPrefix(trimmed, "bq. ") ||
+		trimmed == "----" ||
+		strings.HasPrefix(trimmed, "|") ||
+		wikiListPattern.MatchString(line)
+}
+
+// parseWikiMacro parses {name[:params]}...{name}, which may open and close
+// on the same line.
+func parseWikiMacro(lines []string, start int) (*Node, int) {
+	m := wikiMacroPattern.FindStringSubmatch(lines[start])
+	name, params, rest := m[1], m[2], m[3]
+	closing := "{" + name + "}"
+
+	var body []string
+	i := start + 1
+	if idx := strings.Index(rest, closing); idx >= 0 {
+		body = append(body, rest[:idx])
+	} else {
+		if strings.TrimSpace(rest) != "" {
+			body = append(body, rest)
+		}
+		for ; i < len(lines); i++ {
+			if idx := strings.Index(lines[i], closing); idx >= 0 {
+				if before := lines[i][:idx]; strings.TrimSpace(before) != "" {
+					body = append(body, before)
+				}
+				i++
+				break
+			}
+			body = append(body, lines[i])
+		}
+	}
+
+	switch name {
+	case "code", "noformat":
+		block := &Node{Type: TypeCodeBlock}
+		if language := wikiCodeLanguage(params); name == "code" && language != "" {
+			block.Attrs = map[string]interface{}{"language": language}
+		}
+		if text := strings.Join(body, "\n"); text != "" {
+			block.Content = []*Node{{Type: TypeText, Text: text}}
+		}
+		return block, i
+
+	case "quote":
+		return &Node{Type: TypeBlockquote, Content: nonEmpty(parseWikiBlocks(body))}, i
+	}
+
+	panel := &Node{
+		Type:    TypePanel,
+		Attrs:   map[string]interface{}{"panelType": wikiPanelTypes[name]},
+		Content: parseWikiBlocks(body),
+	}
+	if title := wikiMacroParam(params, "title"); title != "" {
+		heading := &Node{Type: TypeParagraph, Content: []*Node{{Type: TypeText, Text: title, Marks: []Mark{{Type: MarkStrong}}}}}
+		panel.Content = append([]*Node{heading}, panel.Content...)
+	}
+	panel.Content = nonEmpty(panel.Content)
+	return panel, i
+}
+
+// wikiCodeLanguage picks the language out of {code:params}: the first
+// parameter when it isn't key=value, or language=... otherwise.
+func wikiCodeLanguage(params string) string {
+	if params == "" {
+		return ""
+	}
+	first := strings.SplitN(params, "|", 2)[0]
+	if !strings.Contains(first, "=") {
+		return first
+	}
+	return wikiMacroParam(params, "language")
+}
+
+func wikiMacroParam(params, key string) string {
+	for _, param := range strings.Split(params, "|") {
+		if k, v, ok := strings.Cut(param, "="); ok && strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+func parseWikiTable(lines []string, start int) (*Node, int) {
+	table := &Node{
+		Type:  TypeTable,
+		Attrs: map[string]interface{}{"isNumberColumnEnabled": false, "layout": "default"},
+	}
+
+	i := start
+	for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+		row := &Node{Type: TypeTableRow}
+		line := strings.TrimSpace(lines[i])
+
+		for line != "" && line != "|" && line != "||" {
+			cellType := TypeTableCell
+			if strings.HasPrefix(line, "||") {
+				cellType = TypeTableHeader
+				line = line[2:]
+			} else {
+				line = line[1:]
+			}
+
+			end := wikiCellEnd(line)
+			cell := strings.TrimSpace(line[:end])
+			line = line[end:]
+
+			row.Content = append(row.Content, &Node{
+				Type:    cellType,
+				Attrs:   map[string]interface{}{},
+				Content: []*Node{{Type: TypeParagraph, Content: parseWikiInline(cell, nil)}},
+			})
+		}
+		table.Content = append(table.Content, row)
+	}
+	return table, i
+}
+
+// wikiCellEnd finds the next cell separator, skipping escaped pipes and
+// pipes inside [links].
+func wikiCellEnd(line string) int {
+	depth := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case '|':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(line)
+}
+
+// parseWikiList parses items at the depth of the first item's marker (the
+// length of its run of * or #). Deeper markers become nested lists inside
+// the preceding item.
+func parseWikiList(lines []string, start int) (*Node, int) {
+	m := wikiListPattern.FindStringSubmatch(lines[start])
+	marker := m[1]
+	if marker == "-" {
+		marker = "*"
+	}
+	depth := len(marker)
+
+	list := &Node{Type: TypeBulletList}
+	if marker[len(marker)-1] == '#' {
+		list.Type = TypeOrderedList
+	}
+
+	i := start
+	for i < len(lines) {
+		m := wikiListPattern.FindStringSubmatch(lines[i])
+		if m == nil {
+			break
+		}
+		itemMarker := m[1]
+		if itemMarker == "-" {
+			itemMarker = "*"
+		}
+
+		switch {
+		case len(itemMarker) > depth && len(list.Content) > 0:
+			var nested *Node
+			nested, i = parseWikiList(lines, i)
+			last := list.Content[len(list.Content)-1]
+			last.Content = append(last.Content, nested)
+			continue
+		case len(itemMarker) != depth || itemMarker[depth-1] != marker[len(marker)-1]:
+			return list, i
+		}
+
+		list.Content = append(list.Content, &Node{
+			Type:    TypeListItem,
+			Content: []*Node{{Type: TypeParagraph, Content: parseWikiInline(m[2], nil)}},
+		})
+		i++
+	}
+	return list, i
+}
+
+// wikiMarks maps single-character wiki delimiters to ADF marks.
+var wikiMarks = map[byte]string{
+	'*': MarkStrong,
+	'_': MarkEm,
+	'-': MarkStrike,
+	'+': MarkUnderline,
+}
+
+func parseWikiInline(s string, marks []Mark) []*Node {
+	var nodes []*Node
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, textWithBreaks(text.String(), marks)...)
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+
+		switch {
+		case strings.HasPrefix(rest, `\\`):
+			flush()
+			nodes = append(nodes, &Node{Type: TypeHardBreak})
+			i += 2
+			continue
+
+		case rest[0] == '\\' && len(rest) > 1:
+			text.WriteByte(rest[1])
+			i += 2
+			continue
+
+		case strings.HasPrefix(rest, "{{"):
+			if end := wikiIndex(rest[2:], "}}"); end > 0 {
+				flush()
+				nodes = append(nodes, &Node{Type: TypeText, Text: wikiUnescape(rest[2 : 2+end]), Marks: codeMarks(marks)})
+				i += end + 4
+				continue
+			}
+
+		case strings.HasPrefix(rest, "[~"):
+			if end := strings.IndexByte(rest, ']'); end > 2 {
+				flush()
+				nodes = append(nodes, mentionNode(rest[2:end]))
+				i += end + 1
+				continue
+			}
+
+		case rest[0] == '[':
+			if end := wikiIndex(rest, "]"); end > 1 {
+				flush()
+				label, href := rest[1:end], rest[1:end]
+				if sep := wikiIndex(label, "|"); sep >= 0 {
+					label, href = label[:sep], label[sep+1:]
+				}
+				href = strings.TrimSpace(href)
+				nodes = append(nodes, parseWikiInline(label, withMark(marks, linkMark(href)))...)
+				i += end + 1
+				continue
+			}
+
+		case rest[0] == '!':
+			// !image.png! or !image.png|thumbnail! embeds an attachment.
+			if end := strings.IndexByte(rest[1:], '!'); end > 0 && !strings.ContainsAny(rest[1:1+end], " \n") {
+				flush()
+				name := strings.SplitN(rest[1:1+end], "|", 2)[0]
+				nodes = append(nodes, &Node{
+					Type:    TypeMediaSingle,
+					Content: []*Node{{Type: TypeMedia, Attrs: map[string]interface{}{"alt": name}}},
+				})
+				i += end + 2
+				continue
+			}
+
+		case wikiMarks[rest[0]] != "":
+			if inner, n, ok := wikiDelimited(s, i); ok {
+				flush()
+				nodes = append(nodes, parseWikiInline(inner, withMark(marks, Mark{Type: wikiMarks[rest[0]]}))...)
+				i += n
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		text.WriteRune(r)
+		i += size
+	}
+
+	flush()
+	return nodes
+}
+
+// wikiDelimited matches *bold*, _em_, -strike- and +underline+. The opener
+// must start a word and the closer end one, so "a-b-c" stays literal.
+func wikiDelimited(s string, i int) (inner string, n int, ok bool) {
+	delim := s[i]
+	if i > 0 && isWordByte(s[i-1]) {
+		return "", 0, false
+	}
+	start := i + 1
+	if start >= len(s) || s[start] == ' ' || s[start] == delim {
+		return "", 0, false
+	}
+	for end := start + 1; end < len(s); end++ {
+		if s[end] == '\n' {
+			return "", 0, false
+		}
+		if s[end] != delim || s[end-1] == ' ' || s[end-1] == '\\' {
+			continue
+		}
+		if end+1 < len(s) && isWordByte(s[end+1]) {
+			continue
+		}
+		return s[start:end], end + 1 - i, true
+	}
+	return "", 0, false
+}
+
+// wikiIndex is strings.Index ignoring backslash-escaped characters.
+func wikiIndex(s, substr string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// wikiUnescape drops the backslash from escaped punctuation, for text that
+// isn't parsed any further, such as {{monospace}}.
+func wikiUnescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(wikiEscapable, s[i+1]) >= 0 {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// wikiEscapable lists the characters escapeWiki may put a backslash before.
+const wikiEscapable = `[]{}|!?*_-+^~#`
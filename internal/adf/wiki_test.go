@@ -0,0 +1,87 @@
+package adf
+
+import "testing"
+
+func TestMarkdownToWiki(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"plain", "hello world", "hello world"},
+		{"brackets", "read arr[0] first", `read arr\[0\] first`},
+		{"braces", "type {code} here", `type \{code\} here`},
+		{"dashed word", "pass -flag- through", `pass \-flag\- through`},
+		{"inner punctuation", "a-b snake_case 2 * 3 a - b", "a-b snake_case 2 * 3 a - b"},
+		{"image syntax", "!shot.png!", `\!shot.png!`},
+		{"list-like line", `\* not a list`, `\* not a list`},
+		{"heading-like line", "h1. not a heading", `\h1. not a heading`},
+		{"bold", "**bold**", "*bold*"},
+		{"nested marks", "**bold _it_**", "*bold _it_*"},
+		{"adjacent marks", "**a** and *b*", "*a* and _b_"},
+		{"code", "`x[0] | y`", `{{x\[0\] \| y}}`},
+		{"link", "[the docs](https://example.com)", "[the docs|https://example.com]"},
+		{"bold link", "[**the** docs](https://example.com)", "[*the* docs|https://example.com]"},
+		{"bare link", "https://example.com/a_b", "[https://example.com/a_b]"},
+		{"heading", "## Title", "h2. Title"},
+		{"lists", "- one\n- two\n  1. nested", "* one\n* two\n*# nested"},
+		{"code block", "```go\nx := []int{1}\n```", "{code:go}\nx := []int{1}\n{code}"},
+		{"table pipe", "| a | b |\n| --- | --- |\n| x \\| y | z |", "||a||b||\n|x \\| y|z|"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToWiki(tt.markdown); got != tt.want {
+				t.Errorf("MarkdownToWiki(%q) = %q, want %q", tt.markdown, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToWikiMergesEqualMarks(t *testing.T) {
+	strong := []Mark{{Type: MarkStrong}}
+	doc := Doc(&Node{Type: TypeParagraph, Content: []*Node{
+		{Type: TypeText, Text: "one ", Marks: strong},
+		{Type: TypeText, Text: "two", Marks: strong},
+		{Type: TypeText, Text: " three"},
+	}})
+	if got, want := ToWiki(doc), "*one two* three"; got != want {
+		t.Errorf("ToWiki() = %q, want %q", got, want)
+	}
+}
+
+func TestWikiRoundTrip(t *testing.T) {
+	tests := []string{
+		"read arr[0] first",
+		"type {code} here",
+		"pass -flag- and +x+ through",
+		"a | b",
+		"see !shot.png! ??cite??",
+		"# not a heading",
+	}
+	for _, text := range tests {
+		t.Run(text, func(t *testing.T) {
+			doc := FromWiki(ToWiki(Doc(Paragraph(text))))
+			if got := plainText(doc); got != text {
+				t.Errorf("round trip of %q = %q", text, got)
+			}
+		})
+	}
+
+	t.Run("table cell", func(t *testing.T) {
+		cell := &Node{Type: TypeTableCell, Content: []*Node{Paragraph("x | y")}}
+		table := &Node{Type: TypeTable, Content: []*Node{{Type: TypeTableRow, Content: []*Node{cell}}}}
+		row := FromWiki(ToWiki(Doc(table))).Content[0].Content[0]
+		if len(row.Content) != 1 || plainText(row.Content[0]) != "x | y" {
+			t.Errorf("cell split: %q", ToWiki(Doc(table)))
+		}
+	})
+
+	t.Run("code", func(t *testing.T) {
+		code := &Node{Type: TypeText, Text: "m[k]}}", Marks: []Mark{{Type: MarkCode}}}
+		doc := FromWiki(ToWiki(Doc(&Node{Type: TypeParagraph, Content: []*Node{code}})))
+		got := doc.Content[0].Content[0]
+		if got.Text != code.Text || !hasMark(got.Marks, MarkCode) {
+			t.Errorf("code round trip = %+v", got)
+		}
+	})
+}
@@ -0,0 +1,310 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The Agile REST API is the same on Jira Cloud and Server/DC.
+const agileAPI = "/rest/agile/1.0"
+
+// agileMoveLimit is the most issues one sprint or backlog move accepts.
+const agileMoveLimit = 50
+
+// Sprint states.
+const (
+	SprintActive = "active"
+	SprintFuture = "future"
+	SprintClosed = "closed"
+)
+
+type Board struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Type     string        `json:"type"` // "scrum", "kanban" or "simple"
+	Location BoardLocation `json:"location"`
+}
+
+type BoardLocation struct {
+	ProjectKey  string `json:"projectKey"`
+	ProjectName string `json:"projectName"`
+}
+
+type Sprint struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	State         string   `json:"state"`
+	Goal          string   `json:"goal"`
+	StartDate     JiraTime `json:"startDate"`
+	EndDate       JiraTime `json:"endDate"`
+	CompleteDate  JiraTime `json:"completeDate"`
+	OriginBoardID int      `json:"originBoardId"`
+}
+
+// agilePage is the paging envelope the Agile API wraps lists in.
+type agilePage struct {
+	StartAt    int               `json:"startAt"`
+	MaxResults int               `json:"maxResults"`
+	Total      int               `json:"total"`
+	IsLast     bool              `json:"isLast"`
+	Values     []json.RawMessage `json:"values"`
+}
+
+// agileList fetches every page of an Agile API list, decoding each value
+// with add.
+func (c *Client) agileList(ctx context.Context, endpoint string, params url.Values, add func(json.RawMessage) error) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	startAt := 0
+	for {
+		params.Set("startAt", strconv.Itoa(startAt))
+		resp, err := c.doRequest(ctx, "GET", agileAPI+endpoint+"?"+params.Encode(), nil)
+		if err != nil {
+			return err
+		}
+
+		var page agilePage
+		err = decodeJSON(resp, &page)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, value := range page.Values {
+			if err := add(value); err != nil {
+				return fmt.Errorf("decoding %s: %w", endpoint, err)
+			}
+		}
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || (page.Total > 0 && startAt >= page.Total) {
+			return nil
+		}
+	}
+}
+
+func (c *Client) agilePost(ctx context.Context, endpoint string, body interface{}, result interface{}) error {
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", agileAPI+endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if result == nil {
+		return checkResponse(resp)
+	}
+	return decodeJSON(resp, result)
+}
+
+func (c *Client) GetBoards(projectKey, boardType string) ([]Board, error) {
+	return c.GetBoardsContext(context.Background(), projectKey, boardType)
+}
+
+// GetBoardsContext lists the boards of a project, or every board visible to
+// the user when projectKey is empty. boardType ("scrum" or "kanban")
+// narrows the list if set.
+func (c *Client) GetBoardsContext(ctx context.Context, projectKey, boardType string) ([]Board, error) {
+	params := url.Values{}
+	if projectKey != "" {
+		params.Set("projectKeyOrId", projectKey)
+	}
+	if boardType != "" {
+		params.Set("type", boardType)
+	}
+
+	var boards []Board
+	err := c.agileList(ctx, "/board", params, func(raw json.RawMessage) error {
+		var board Board
+		if err := json.Unmarshal(raw, &board); err != nil {
+			return err
+		}
+		boards = append(boards, board)
+		return nil
+	})
+	return boards, err
+}
+
+func (c *Client) GetBoard(boardID int) (*Board, error) {
+	return c.GetBoardContext(context.Background(), boardID)
+}
+
+func (c *Client) GetBoardContext(ctx context.Context, boardID int) (*Board, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/board/%d", agileAPI, boardID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var board Board
+	return &board, decodeJSON(resp, &board)
+}
+
+func (c *Client) GetSprints(boardID int, states ...string) ([]Sprint, error) {
+	return c.GetSprintsContext(context.Background(), boardID, states...)
+}
+
+// GetSprintsContext lists a board's sprints in the states given (all states
+// if none), in the board's order: closed, then active, then future.
+func (c *Client) GetSprintsContext(ctx context.Context, boardID int, states ...string) ([]Sprint, error) {
+	params := url.Values{}
+	if len(states) > 0 {
+		params.Set("state", strings.Join(states, ","))
+	}
+
+	var sprints []Sprint
+	err := c.agileList(ctx, fmt.Sprintf("/board/%d/sprint", boardID), params, func(raw json.RawMessage) error {
+		var sprint Sprint
+		if err := json.Unmarshal(raw, &sprint); err != nil {
+			return err
+		}
+		sprints = append(sprints, sprint)
+		return nil
+	})
+	return sprints, err
+}
+
+func (c *Client) GetSprint(sprintID int) (*Sprint, error) {
+	return c.GetSprintContext(context.Background(), sprintID)
+}
+
+func (c *Client) GetSprintContext(ctx context.Context, sprintID int) (*Sprint, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/sprint/%d", agileAPI, sprintID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var sprint Sprint
+	return &sprint, decodeJSON(resp, &sprint)
+}
+
+func (c *Client) GetSprintIssues(sprintID int, jql string, limit int) (*SearchResults, error) {
+	return c.GetSprintIssuesContext(context.Background(), sprintID, jql, limit)
+}
+
+// GetSprintIssuesContext returns the sprint's issues in rank order, further
+// filtered by jql if set. It goes through the regular search so the issues
+// have the same fields as any other listing.
+func (c *Client) GetSprintIssuesContext(ctx context.Context, sprintID int, jql string, limit int) (*SearchResults, error) {
+	query := fmt.Sprintf("sprint = %d", sprintID)
+	if jql != "" {
+		query += " AND (" + jql + ")"
+	}
+	return c.SearchIssuesContext(ctx, query+" ORDER BY Rank ASC", limit)
+}
+
+func (c *Client) MoveIssuesToSprint(sprintID int, issueKeys []string) error {
+	return c.MoveIssuesToSprintContext(context.Background(), sprintID, issueKeys)
+}
+
+func (c *Client) MoveIssuesToSprintContext(ctx context.Context, sprintID int, issueKeys []string) error {
+	return c.moveIssues(ctx, fmt.Sprintf("/sprint/%d/issue", sprintID), issueKeys)
+}
+
+func (c *Client) MoveIssuesToBacklog(issueKeys []string) error {
+	return c.MoveIssuesToBacklogContext(context.Background(), issueKeys)
+}
+
+// MoveIssuesToBacklogContext takes the issues out of whatever sprint
+// they're in.
+func (c *Client) MoveIssuesToBacklogContext(ctx context.Context, issueKeys []string) error {
+	return c.moveIssues(ctx, "/backlog/issue", issueKeys)
+}
+
+func (c *Client) moveIssues(ctx context.Context, endpoint string, issueKeys []string) error {
+	for start := 0; start < len(issueKeys); start += agileMoveLimit {
+		end := start + agileMoveLimit
+		if end > len(issueKeys) {
+			end = len(issueKeys)
+		}
+		body := map[string]interface{}{"issues": issueKeys[start:end]}
+		c.forgetIssues(issueKeys[start:end]...)
+		if err := c.agilePost(ctx, endpoint, body, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) StartSprint(sprintID int, start, end time.Time, goal string) (*Sprint, error) {
+	return c.StartSprintContext(context.Background(), sprintID, start, end, goal)
+}
+
+// StartSprintContext makes a future sprint active. An empty goal keeps the
+// sprint's current goal.
+func (c *Client) StartSprintContext(ctx context.Context, sprintID int, start, end time.Time, goal string) (*Sprint, error) {
+	body := map[string]interface{}{
+		"state":     SprintActive,
+		"startDate": start.Format(time.RFC3339),
+		"endDate":   end.Format(time.RFC3339),
+	}
+	if goal != "" {
+		body["goal"] = goal
+	}
+
+	var sprint Sprint
+	return &sprint, c.agilePost(ctx, fmt.Sprintf("/sprint/%d", sprintID), body, &sprint)
+}
+
+func (c *Client) CloseSprint(sprintID int) (*Sprint, error) {
+	return c.CloseSprintContext(context.Background(), sprintID)
+}
+
+// CloseSprintContext completes an active sprint. Jira moves any unfinished
+// issues to the backlog; move them elsewhere first to keep them planned.
+func (c *Client) CloseSprintContext(ctx context.Context, sprintID int) (*Sprint, error) {
+	var sprint Sprint
+	body := map[string]interface{}{"state": SprintClosed}
+	return &sprint, c.agilePost(ctx, fmt.Sprintf("/sprint/%d", sprintID), body, &sprint)
+}
+
+// BoardConfiguration is a board's column layout and the saved filter that
+// selects its issues.
+type BoardConfiguration struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Filter struct {
+		ID string `json:"id"`
+	} `json:"filter"`
+	ColumnConfig struct {
+		Columns []BoardColumn `json:"columns"`
+	} `json:"columnConfig"`
+}
+
+// BoardColumn is one column of a board: the statuses it shows and its WIP
+// limits, which are zero when not set.
+type BoardColumn struct {
+	Name     string `json:"name"`
+	Statuses []struct {
+		ID string `json:"id"`
+	} `json:"statuses"`
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (c *Client) GetBoardConfiguration(boardID int) (*BoardConfiguration, error) {
+	return c.GetBoardConfigurationContext(context.Background(), boardID)
+}
+
+func (c *Client) GetBoardConfigurationContext(ctx context.Context, boardID int) (*BoardConfiguration, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/board/%d/configuration", agileAPI, boardID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var config BoardConfiguration
+	return &config, decodeJSON(resp, &config)
+}
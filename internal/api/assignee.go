@@ -2,31 +2,25 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 )
 
 func (c *Client) AssignIssue(issueKey, assignee string) error {
-	normalizedAssignee := strings.ToLower(strings.TrimSpace(assignee))
-	if normalizedAssignee == "@me" || normalizedAssignee == "me" {
-		currentUser, err := c.GetCurrentUser()
-		if err != nil {
-			return fmt.Errorf("getting current user: %w", err)
-		}
+	return c.AssignIssueContext(context.Background(), issueKey, assignee)
+}
 
-		if c.AuthType == "pat" {
-			assignee = currentUser.DisplayName
-		} else {
-			assignee = currentUser.AccountID
-		}
+func (c *Client) AssignIssueContext(ctx context.Context, issueKey, assignee string) error {
+	assignee, err := c.resolveAssignee(ctx, assignee)
+	if err != nil {
+		return err
 	}
 
 	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s/assignee", c.getAPIVersion(), issueKey)
 
 	var requestBody []byte
-	var err error
-
 	if c.AuthType == "pat" {
 		requestBody, err = json.Marshal(map[string]string{
 			"name": assignee,
@@ -40,7 +34,7 @@ func (c *Client) AssignIssue(issueKey, assignee string) error {
 		return fmt.Errorf("marshaling assignee: %w", err)
 	}
 
-	resp, err := c.doRequest("PUT", endpoint, bytes.NewReader(requestBody))
+	resp, err := c.doRequest(ctx, "PUT", endpoint, bytes.NewReader(requestBody))
 	if err != nil {
 		return err
 	}
@@ -49,9 +43,32 @@ func (c *Client) AssignIssue(issueKey, assignee string) error {
 	return checkResponse(resp)
 }
 
+// resolveAssignee turns "me"/"@me" into the current user's identifier:
+// the account ID on Cloud or the username on Server/DC. Anything else is
+// passed through unchanged.
+func (c *Client) resolveAssignee(ctx context.Context, assignee string) (string, error) {
+	normalizedAssignee := strings.ToLower(strings.TrimSpace(assignee))
+	if normalizedAssignee != "@me" && normalizedAssignee != "me" {
+		return assignee, nil
+	}
+
+	currentUser, err := c.GetCurrentUserContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("getting current user: %w", err)
+	}
+	if c.AuthType == "pat" {
+		return currentUser.Name, nil
+	}
+	return currentUser.AccountID, nil
+}
+
 func (c *Client) GetCurrentUser() (*User, error) {
+	return c.GetCurrentUserContext(context.Background())
+}
+
+func (c *Client) GetCurrentUserContext(ctx context.Context) (*User, error) {
 	endpoint := fmt.Sprintf("/rest/api/%s/myself", c.getAPIVersion())
-	resp, err := c.doRequest("GET", endpoint, nil)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
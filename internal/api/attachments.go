@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+func (c *Client) GetAttachments(issueKey string) ([]Attachment, error) {
+	return c.GetAttachmentsContext(context.Background(), issueKey)
+}
+
+func (c *Client) GetAttachmentsContext(ctx context.Context, issueKey string) ([]Attachment, error) {
+	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s?fields=attachment", c.getAPIVersion(), issueKey)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var issue Issue
+	if err := decodeJSON(resp, &issue); err != nil {
+		return nil, err
+	}
+	return issue.Fields.Attachments, nil
+}
+
+func (c *Client) AttachFile(issueKey, filename string, content io.Reader, size int64) ([]Attachment, error) {
+	return c.AttachFileContext(context.Background(), issueKey, filename, content, size)
+}
+
+// AttachFileContext uploads size bytes of content as a file named
+// filename. The file is streamed rather than buffered, so large files are
+// fine, but the upload is not retried. A negative size sends the body
+// chunked, which some proxies in front of Jira reject.
+func (c *Client) AttachFileContext(ctx context.Context, issueKey, filename string, content io.Reader, size int64) ([]Attachment, error) {
+	// Render the multipart envelope around the file up front so the
+	// request length is known.
+	var envelope bytes.Buffer
+	form := multipart.NewWriter(&envelope)
+	if _, err := form.CreateFormFile("file", filename); err != nil {
+		return nil, fmt.Errorf("building upload: %w", err)
+	}
+	headLen := envelope.Len()
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("building upload: %w", err)
+	}
+	head, tail := envelope.Bytes()[:headLen], envelope.Bytes()[headLen:]
+	body := io.MultiReader(bytes.NewReader(head), content, bytes.NewReader(tail))
+
+	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s/attachments", c.getAPIVersion(), issueKey)
+	req, err := c.newRequest(ctx, "POST", endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = int64(envelope.Len()) + size
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	// Jira rejects multipart requests without this as a CSRF precaution.
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	resp, err := c.doStream(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var attachments []Attachment
+	return attachments, decodeJSON(resp, &attachments)
+}
+
+func (c *Client) DownloadAttachment(attachment *Attachment) (io.ReadCloser, error) {
+	return c.DownloadAttachmentContext(context.Background(), attachment)
+}
+
+// DownloadAttachmentContext opens the attachment's content for streaming;
+// the caller must close it. The file is fetched from the configured Jira
+// URL rather than the attachment's Content URL, so credentials are never
+// sent to another host. Jira Cloud redirects to its media service, and
+// the redirect carries its own token.
+func (c *Client) DownloadAttachmentContext(ctx context.Context, attachment *Attachment) (io.ReadCloser, error) {
+	var endpoint string
+	if c.AuthType == "pat" {
+		endpoint = fmt.Sprintf("/secure/attachment/%s/%s", url.PathEscape(attachment.ID), url.PathEscape(attachment.Filename))
+	} else {
+		endpoint = fmt.Sprintf("/rest/api/3/attachment/content/%s", url.PathEscape(attachment.ID))
+	}
+
+	req, err := c.newRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	resp, err := c.doStream(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// FindAttachment picks an attachment by ID or by file name (ignoring case).
+func FindAttachment(attachments []Attachment, nameOrID string) (*Attachment, error) {
+	var matches []*Attachment
+	for i := range attachments {
+		if attachments[i].ID == nameOrID {
+			return &attachments[i], nil
+		}
+		if strings.EqualFold(attachments[i].Filename, nameOrID) {
+			matches = append(matches, &attachments[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no attachment named %q: %w", nameOrID, ErrNotFound)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ID
+	}
+	return nil, fmt.Errorf("%d attachments are named %q; pick one by ID (%s)", len(matches), nameOrID, strings.Join(ids, ", "))
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+)
+
+// ErrOffline is returned for requests that would need Jira while the
+// cache is in offline mode.
+var ErrOffline = errors.New("not available offline")
+
+// Names of the metadata entries in the cache.
+const (
+	metaFields     = "fields"
+	metaPriorities = "priorities"
+	metaLinkTypes  = "linktypes"
+	metaProjects   = "projects"
+)
+
+// issueEndpoint matches the endpoints of one issue, capturing its key.
+var issueEndpoint = regexp.MustCompile(`^/rest/api/[0-9]+/issue/([^/?]+)`)
+
+// cachedSearch is a stored search: the issues found for jql, fetched with
+// limit (0 for all of them).
+type cachedSearch struct {
+	JQL     string         `json:"jql"`
+	Limit   int            `json:"limit"`
+	Results *SearchResults `json:"results"`
+}
+
+// beforeRequest refuses requests while offline and, for anything but a
+// read, drops the cached data the request may change.
+func (c *Client) beforeRequest(method, endpoint string) error {
+	if c.Cache == nil {
+		return nil
+	}
+	if c.Cache.Mode == cache.ModeOffline {
+		if method == "GET" {
+			return fmt.Errorf("%w: %s is not cached (run 'jira sync' while online)", ErrOffline, strings.SplitN(endpoint, "?", 2)[0])
+		}
+		return fmt.Errorf("%w: changing Jira needs a connection", ErrOffline)
+	}
+	if method != "GET" {
+		c.invalidate(endpoint)
+	}
+	return nil
+}
+
+// invalidate drops the issue a write to endpoint names, with its comments,
+// and every stored search, since any write can change what they match.
+func (c *Client) invalidate(endpoint string) {
+	if m := issueEndpoint.FindStringSubmatch(endpoint); m != nil {
+		key := strings.ToUpper(m[1])
+		c.Cache.Delete(cache.KindIssue, key)
+		c.Cache.Delete(cache.KindComments, key)
+	}
+	c.Cache.DeleteKind(cache.KindSearch)
+}
+
+// forgetIssues drops issues a write changes without naming them in its
+// endpoint, such as both ends of a link or issues moved between sprints.
+func (c *Client) forgetIssues(keys ...string) {
+	if c.Cache == nil {
+		return
+	}
+	for _, key := range keys {
+		c.Cache.Delete(cache.KindIssue, strings.ToUpper(key))
+	}
+}
+
+// The cache is best effort: failing to write it never fails a request.
+
+// cacheIssues stores issues fetched from Jira. Stored comments of an issue
+// that was updated since are dropped, as they may be out of date.
+func (c *Client) cacheIssues(issues []Issue) {
+	if c.Cache == nil {
+		return
+	}
+	for i := range issues {
+		issue := &issues[i]
+		var old Issue
+		if _, ok := c.Cache.Get(cache.KindIssue, issue.Key, &old); ok && !old.Fields.Updated.Equal(issue.Fields.Updated.Time) {
+			c.Cache.Delete(cache.KindComments, issue.Key)
+		}
+		c.Cache.Put(cache.KindIssue, issue.Key, issue)
+	}
+}
+
+func (c *Client) lookupCache(kind, key string, v interface{}) bool {
+	return c.Cache != nil && c.Cache.Lookup(kind, key, v)
+}
+
+func (c *Client) storeCache(kind, key string, v interface{}) {
+	if c.Cache != nil {
+		c.Cache.Put(kind, key, v)
+	}
+}
+
+// DropCachedSearches makes the next searches ask Jira, for an explicit
+// refresh. Offline, the stored searches are all there is, so they're kept.
+func (c *Client) DropCachedSearches() {
+	if c.Cache != nil && c.Cache.Mode != cache.ModeOffline {
+		c.Cache.DeleteKind(cache.KindSearch)
+	}
+}
+
+// searchCacheKey names a search by the user running it as well as jql,
+// since queries like "assignee = currentUser()" find different issues for
+// different accounts.
+func searchCacheKey(user, jql string) string {
+	sum := sha256.Sum256([]byte(user + "\n" + strings.TrimSpace(jql)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// lookupSearch returns stored results for jql if they hold the first
+// limit matches.
+func (c *Client) lookupSearch(jql string, limit int) (*SearchResults, bool) {
+	if c.Cache == nil {
+		return nil, false
+	}
+	var stored cachedSearch
+	if !c.lookupCache(cache.KindSearch, searchCacheKey(c.Cache.User, jql), &stored) || stored.Results == nil {
+		return nil, false
+	}
+	complete := stored.Limit <= 0 || len(stored.Results.Issues) < stored.Limit
+	if stored.JQL != strings.TrimSpace(jql) || !(complete || (limit > 0 && limit <= stored.Limit)) {
+		return nil, false
+	}
+
+	results := *stored.Results
+	if limit > 0 && len(results.Issues) > limit {
+		results.Issues = results.Issues[:limit]
+		results.MaxResults = limit
+	}
+	return &results, true
+}
+
+func (c *Client) storeSearch(jql string, limit int, results *SearchResults) {
+	if c.Cache == nil {
+		return
+	}
+	c.cacheIssues(results.Issues)
+	c.storeCache(cache.KindSearch, searchCacheKey(c.Cache.User, jql), cachedSearch{
+		JQL:     strings.TrimSpace(jql),
+		Limit:   limit,
+		Results: results,
+	})
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+)
+
+// fakeJira answers the endpoints the cache tests use and counts requests.
+type fakeJira struct {
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func (f *fakeJira) count(route string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.hits[route]
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route := r.Method + " " + r.URL.Path
+	f.mu.Lock()
+	f.hits[route]++
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch route {
+	case "GET /rest/api/3/issue/A-1", "GET /rest/api/3/issue/B-2":
+		fmt.Fprintf(w, `{"id":"1","key":%q,"fields":{"summary":"s"}}`, r.URL.Path[len("/rest/api/3/issue/"):])
+	case "GET /rest/api/3/issueLinkType":
+		fmt.Fprint(w, `{"issueLinkTypes":[{"id":"1","name":"Blocks","inward":"is blocked by","outward":"blocks"}]}`)
+	case "POST /rest/api/3/issueLink":
+		w.WriteHeader(http.StatusCreated)
+	case "GET /rest/api/3/issueLink/10":
+		fmt.Fprint(w, `{"id":"10","inwardIssue":{"key":"A-1"},"outwardIssue":{"key":"B-2"}}`)
+	case "DELETE /rest/api/3/issueLink/10":
+		w.WriteHeader(http.StatusNoContent)
+	case "POST /rest/agile/1.0/sprint/5/issue":
+		w.WriteHeader(http.StatusNoContent)
+	case "GET /rest/api/3/search/jql":
+		fmt.Fprint(w, `{"issues":[{"id":"1","key":"A-1","fields":{"summary":"s"}}],"isLast":true}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newCachedClient(t *testing.T) (*Client, *fakeJira) {
+	t.Helper()
+	fake := &fakeJira{hits: map[string]int{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, "a@example.com", "token")
+	client.Cache = &cache.Cache{Dir: t.TempDir(), Mode: cache.ModeNormal, TTL: time.Hour, User: "a@example.com"}
+	return client, fake
+}
+
+func TestWritesDropTheIssuesTheyChange(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		write func(c *Client) error
+	}{
+		{"link", func(c *Client) error {
+			_, err := c.LinkIssuesContext(ctx, "B-2", "blocks", "A-1")
+			return err
+		}},
+		{"delete link", func(c *Client) error {
+			return c.DeleteIssueLinkContext(ctx, "10")
+		}},
+		{"move to sprint", func(c *Client) error {
+			return c.MoveIssuesToSprintContext(ctx, 5, []string{"A-1"})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newCachedClient(t)
+			for i := 0; i < 2; i++ {
+				if _, err := client.GetIssueContext(ctx, "A-1"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := fake.count("GET /rest/api/3/issue/A-1"); got != 1 {
+				t.Fatalf("fetched A-1 %d times before the write, want 1", got)
+			}
+
+			if err := tt.write(client); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetIssueContext(ctx, "A-1"); err != nil {
+				t.Fatal(err)
+			}
+			if got := fake.count("GET /rest/api/3/issue/A-1"); got != 2 {
+				t.Errorf("fetched A-1 %d times after the write, want 2", got)
+			}
+		})
+	}
+}
+
+func TestSearchCacheIsPerUser(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newCachedClient(t)
+	jql := "assignee = currentUser()"
+
+	for _, user := range []string{"a@example.com", "a@example.com", "b@example.com"} {
+		client.Cache.User = user
+		if _, err := client.SearchIssuesContext(ctx, jql, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := fake.count("GET /rest/api/3/search/jql"); got != 2 {
+		t.Errorf("searched Jira %d times, want 2 (once per user)", got)
+	}
+}
+
+func TestSearchWithoutCache(t *testing.T) {
+	client, fake := newCachedClient(t)
+	client.Cache = nil
+
+	for i := 0; i < 2; i++ {
+		results, err := client.SearchIssuesContext(context.Background(), "project = A", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results.Issues) != 1 {
+			t.Fatalf("found %d issues, want 1", len(results.Issues))
+		}
+	}
+	if got := fake.count("GET /rest/api/3/search/jql"); got != 2 {
+		t.Errorf("searched Jira %d times, want 2", got)
+	}
+}
+
+func TestGetFreshIssueSkipsTheCache(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newCachedClient(t)
+
+	for _, get := range []func(context.Context, string) (*Issue, error){
+		client.GetIssueContext, client.GetIssueContext, client.GetFreshIssueContext, client.GetIssueContext,
+	} {
+		if _, err := get(ctx, "A-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := fake.count("GET /rest/api/3/issue/A-1"); got != 2 {
+		t.Errorf("fetched A-1 %d times, want 2", got)
+	}
+}
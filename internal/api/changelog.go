@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func (c *Client) GetChangelog(issueKey string) ([]ChangelogHistory, error) {
+	return c.GetChangelogContext(context.Background(), issueKey)
+}
+
+// GetChangelogContext returns the issue's change history, newest first.
+func (c *Client) GetChangelogContext(ctx context.Context, issueKey string) ([]ChangelogHistory, error) {
+	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s?fields=status&expand=changelog", c.getAPIVersion(), issueKey)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var issue Issue
+	if err := decodeJSON(resp, &issue); err != nil {
+		return nil, err
+	}
+	if issue.Changelog == nil {
+		return nil, nil
+	}
+
+	histories := issue.Changelog.Histories
+	sort.SliceStable(histories, func(i, j int) bool {
+		return histories[i].Created.After(histories[j].Created.Time)
+	})
+	return histories, nil
+}
+
+func (c *Client) PreviousStatus(issueKey, currentStatus string) (string, error) {
+	return c.PreviousStatusContext(context.Background(), issueKey, currentStatus)
+}
+
+// PreviousStatusContext returns the status the issue had before it last
+// moved into currentStatus, or "" if the changelog doesn't record that.
+func (c *Client) PreviousStatusContext(ctx context.Context, issueKey, currentStatus string) (string, error) {
+	histories, err := c.GetChangelogContext(ctx, issueKey)
+	if err != nil {
+		return "", fmt.Errorf("fetching changelog: %w", err)
+	}
+
+	for _, history := range histories {
+		for _, item := range history.Items {
+			if item.Field == "status" && strings.EqualFold(item.ToString, currentStatus) {
+				return item.FromString, nil
+			}
+		}
+	}
+	return "", nil
+}
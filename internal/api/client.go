@@ -1,11 +1,18 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/adf"
+	"github.com/danielyan21/JiraCLI/internal/cache"
 )
 
 type Client struct {
@@ -14,6 +21,21 @@ type Client struct {
 	APIToken   string
 	AuthType   string // "basic" or "pat"
 	HTTPClient *http.Client
+	Retry      RetryPolicy
+
+	// TokenSource, if set, supplies APIToken on the first request instead of
+	// up front, so keyrings and token commands are only consulted when needed.
+	TokenSource func(ctx context.Context) (string, error)
+	tokenMu     sync.Mutex
+
+	// fields caches the instance's field metadata; it rarely changes and
+	// every custom field lookup needs it.
+	fields   []Field
+	fieldsMu sync.Mutex
+
+	// Cache, if set, answers reads from disk when it can and stores what
+	// Jira returns; writes drop the entries they affect.
+	Cache *cache.Cache
 }
 
 func NewClient(baseURL, email, apiToken string) *Client {
@@ -25,6 +47,7 @@ func NewClient(baseURL, email, apiToken string) *Client {
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		Retry: DefaultRetryPolicy(),
 	}
 }
 
@@ -37,41 +60,141 @@ func NewClientWithAuthType(baseURL, email, apiToken, authType string) *Client {
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		Retry: DefaultRetryPolicy(),
+	}
+}
+
+// doRequest sends the request, retrying rate-limited (429) and transient
+// 502/503/504 responses and network errors according to c.Retry. The final
+// response is returned unchecked, so callers still run it through checkResponse.
+// Cancelling ctx aborts both in-flight requests and pending retry waits.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	if err := c.beforeRequest(method, endpoint); err != nil {
+		return nil, err
+	}
+
+	// Buffer the body so it can be replayed on retries.
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+	}
+
+	canRetry := c.Retry.canRetryMethod(method)
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendRequest(ctx, method, endpoint, payload)
+		lastAttempt := !canRetry || attempt >= c.Retry.MaxRetries
+
+		if err != nil {
+			if lastAttempt || ctx.Err() != nil {
+				return nil, err
+			}
+		} else if lastAttempt || !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		delay, ok := c.Retry.retryDelay(attempt, resp)
+		if !ok {
+			return resp, nil
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
 	}
 }
 
-func (c *Client) doRequest(method, endpoint string, body io.Reader) (*http.Response, error) {
-	url := c.BaseURL + endpoint
+func (c *Client) sendRequest(ctx context.Context, method, endpoint string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := c.newRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing request: %w", err)
+	}
+
+	return resp, nil
+}
 
-	req, err := http.NewRequest(method, url, body)
+// newRequest builds an authenticated request for an API endpoint. The body
+// is taken to be JSON; callers sending something else set Content-Type on
+// the returned request.
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+endpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "JiraCLI/0.1.0")
 
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if c.AuthType == "pat" {
-		req.Header.Set("Authorization", "Bearer "+c.APIToken)
+		req.Header.Set("Authorization", "Bearer "+token)
 	} else {
-		req.SetBasicAuth(c.Email, c.APIToken)
+		req.SetBasicAuth(c.Email, token)
 	}
+	return req, nil
+}
 
-	resp, err := c.HTTPClient.Do(req)
+// doStream sends a request whose body or response may be too large to
+// finish within HTTPClient's timeout, such as a file upload or download.
+// It isn't retried; ctx is the only deadline.
+func (c *Client) doStream(req *http.Request) (*http.Response, error) {
+	if err := c.beforeRequest(req.Method, strings.TrimPrefix(req.URL.String(), c.BaseURL)); err != nil {
+		return nil, err
+	}
+
+	client := *c.HTTPClient
+	client.Timeout = 0
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error performing request: %w", err)
 	}
-
 	return resp, nil
 }
 
+func (c *Client) token(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.APIToken == "" && c.TokenSource != nil {
+		token, err := c.TokenSource(ctx)
+		if err != nil {
+			return "", fmt.Errorf("resolving API token: %w", err)
+		}
+		c.APIToken = token
+	}
+	return c.APIToken, nil
+}
+
+// checkResponse returns an *Error for any non-2xx response.
 func checkResponse(resp *http.Response) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return nil
 	}
-	body, _ := io.ReadAll(resp.Body)
-	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	return newError(resp)
 }
 
 func decodeJSON(resp *http.Response, v interface{}) error {
@@ -88,9 +211,22 @@ func (c *Client) getAPIVersion() string {
 	return "3"
 }
 
+// RichText converts Markdown entered by the user into the rich-text format of
+// the API version in use: ADF for v3 (Cloud), wiki markup for v2 (Server/DC).
+func (c *Client) RichText(markdown string) interface{} {
+	if c.getAPIVersion() == "2" {
+		return adf.MarkdownToWiki(markdown)
+	}
+	return adf.FromMarkdown(markdown)
+}
+
 func (c *Client) TestConnection() error {
+	return c.TestConnectionContext(context.Background())
+}
+
+func (c *Client) TestConnectionContext(ctx context.Context) error {
 	apiVersion := c.getAPIVersion()
-	resp, err := c.doRequest("GET", "/rest/api/"+apiVersion+"/myself", nil)
+	resp, err := c.doRequest(ctx, "GET", "/rest/api/"+apiVersion+"/myself", nil)
 	if err != nil {
 		return err
 	}
@@ -2,9 +2,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
 )
 
 type CommentsResponse struct {
@@ -13,10 +17,19 @@ type CommentsResponse struct {
 }
 
 func (c *Client) GetComments(issueKey string) ([]Comment, error) {
+	return c.GetCommentsContext(context.Background(), issueKey)
+}
+
+func (c *Client) GetCommentsContext(ctx context.Context, issueKey string) ([]Comment, error) {
 	apiVersion := c.getAPIVersion()
 	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s/comment", apiVersion, issueKey)
 
-	resp, err := c.doRequest("GET", endpoint, nil)
+	var comments []Comment
+	if c.lookupCache(cache.KindComments, strings.ToUpper(issueKey), &comments) {
+		return comments, nil
+	}
+
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -36,44 +49,27 @@ func (c *Client) GetComments(issueKey string) ([]Comment, error) {
 		return nil, fmt.Errorf("unmarshaling comments: %w", err)
 	}
 
+	c.storeCache(cache.KindComments, strings.ToUpper(issueKey), result.Comments)
 	return result.Comments, nil
 }
 
 func (c *Client) AddComment(issueKey, comment string) error {
+	return c.AddCommentContext(context.Background(), issueKey, comment)
+}
+
+func (c *Client) AddCommentContext(ctx context.Context, issueKey, comment string) error {
 	apiVersion := c.getAPIVersion()
-	var requestBody []byte
-	var err error
-
-	if c.AuthType == "pat" {
-		requestBody, err = json.Marshal(map[string]string{
-			"body": comment,
-		})
-	} else {
-		// API v3 uses Atlassian Document Format (ADF)
-		requestBody, err = json.Marshal(map[string]interface{}{
-			"body": map[string]interface{}{
-				"type":    "doc",
-				"version": 1,
-				"content": []map[string]interface{}{
-					{
-						"type": "paragraph",
-						"content": []map[string]interface{}{
-							{
-								"type": "text",
-								"text": comment,
-							},
-						},
-					},
-				},
-			},
-		})
-	}
+
+	// Markdown is converted to ADF on v3 and wiki markup on v2
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"body": c.RichText(comment),
+	})
 	if err != nil {
 		return fmt.Errorf("marshaling comment: %w", err)
 	}
 
 	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s/comment", apiVersion, issueKey)
-	resp, err := c.doRequest("POST", endpoint, bytes.NewReader(requestBody))
+	resp, err := c.doRequest(ctx, "POST", endpoint, bytes.NewReader(requestBody))
 	if err != nil {
 		return err
 	}
@@ -2,57 +2,87 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
+// CreateIssueOptions describes a new issue. Description is Markdown and is
+// converted to the instance's rich-text format. Assignee accepts "me"/"@me",
+// an account ID (Cloud) or a username (Server/DC).
+type CreateIssueOptions struct {
+	Project     string
+	Summary     string
+	Description string
+	IssueType   string
+	Priority    string
+	Assignee    string
+	Labels      []string
+	Components  []string
+	Parent      string
+	Fields      map[string]interface{}
+}
+
 func (c *Client) CreateIssue(projectKey, summary, description, issueType, priority string, assignToMe bool) (*CreateIssueResponse, error) {
+	return c.CreateIssueContext(context.Background(), projectKey, summary, description, issueType, priority, assignToMe)
+}
+
+func (c *Client) CreateIssueContext(ctx context.Context, projectKey, summary, description, issueType, priority string, assignToMe bool) (*CreateIssueResponse, error) {
+	opts := CreateIssueOptions{
+		Project:     projectKey,
+		Summary:     summary,
+		Description: description,
+		IssueType:   issueType,
+		Priority:    priority,
+	}
+	if assignToMe {
+		opts.Assignee = "me"
+	}
+	return c.CreateIssueWithOptionsContext(ctx, opts)
+}
+
+func (c *Client) CreateIssueWithOptions(opts CreateIssueOptions) (*CreateIssueResponse, error) {
+	return c.CreateIssueWithOptionsContext(context.Background(), opts)
+}
+
+func (c *Client) CreateIssueWithOptionsContext(ctx context.Context, opts CreateIssueOptions) (*CreateIssueResponse, error) {
 	fields := CreateIssueFields{
 		Project: ProjectRef{
-			Key: projectKey,
+			Key: opts.Project,
 		},
-		Summary: summary,
+		Summary: opts.Summary,
 		IssueType: IssueTypeRef{
-			Name: issueType,
+			Name: opts.IssueType,
 		},
+		Labels: opts.Labels,
+		Extra:  opts.Fields,
 	}
 
-	if description != "" {
-		if c.AuthType == "pat" {
-			fields.Description = description
-		} else {
-			fields.Description = map[string]interface{}{
-				"type":    "doc",
-				"version": 1,
-				"content": []map[string]interface{}{
-					{
-						"type": "paragraph",
-						"content": []map[string]interface{}{
-							{
-								"type": "text",
-								"text": description,
-							},
-						},
-					},
-				},
-			}
-		}
+	if opts.Description != "" {
+		fields.Description = c.RichText(opts.Description)
 	}
 
-	if priority != "" {
-		fields.Priority = &PriorityRef{Name: priority}
+	if opts.Priority != "" {
+		fields.Priority = &PriorityRef{Name: opts.Priority}
 	}
 
-	if assignToMe {
-		currentUser, err := c.GetCurrentUser()
+	for _, component := range opts.Components {
+		fields.Components = append(fields.Components, ComponentRef{Name: component})
+	}
+
+	if opts.Parent != "" {
+		fields.Parent = &IssueRef{Key: opts.Parent}
+	}
+
+	if opts.Assignee != "" {
+		assignee, err := c.resolveAssignee(ctx, opts.Assignee)
 		if err != nil {
-			return nil, fmt.Errorf("getting current user: %w", err)
+			return nil, err
 		}
-
 		if c.AuthType == "pat" {
-			fields.Assignee = &AssigneeRef{Name: currentUser.DisplayName}
+			fields.Assignee = &AssigneeRef{Name: assignee}
 		} else {
-			fields.Assignee = &AssigneeRef{AccountID: currentUser.AccountID}
+			fields.Assignee = &AssigneeRef{AccountID: assignee}
 		}
 	}
 
@@ -62,7 +92,7 @@ func (c *Client) CreateIssue(projectKey, summary, description, issueType, priori
 	}
 
 	endpoint := fmt.Sprintf("/rest/api/%s/issue", c.getAPIVersion())
-	resp, err := c.doRequest("POST", endpoint, bytes.NewReader(requestBody))
+	resp, err := c.doRequest(ctx, "POST", endpoint, bytes.NewReader(requestBody))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+)
+
+// createMetaPageSize is the page size requested from the createmeta
+// endpoints; the server may return fewer.
+const createMetaPageSize = 100
+
+// FieldSchema describes a field's value type as reported by Jira, e.g.
+// {Type: "array", Items: "option"} for a multi-select custom field.
+type FieldSchema struct {
+	Type     string `json:"type"`
+	Items    string `json:"items,omitempty"`
+	System   string `json:"system,omitempty"`
+	Custom   string `json:"custom,omitempty"`
+	CustomID int64  `json:"customId,omitempty"`
+}
+
+// AllowedValue is one choice of a select-style field. Depending on the
+// field it is named by Name (priorities, components, versions) or Value
+// (custom field options).
+type AllowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Label returns the human-readable name of the choice.
+func (v AllowedValue) Label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	if v.Value != "" {
+		return v.Value
+	}
+	return v.ID
+}
+
+// CreateMetaField describes a field on an issue type's create screen.
+type CreateMetaField struct {
+	FieldID         string         `json:"fieldId"`
+	Key             string         `json:"key"`
+	Name            string         `json:"name"`
+	Required        bool           `json:"required"`
+	HasDefaultValue bool           `json:"hasDefaultValue"`
+	Schema          FieldSchema    `json:"schema"`
+	AllowedValues   []AllowedValue `json:"allowedValues,omitempty"`
+}
+
+// ID returns the field's identifier, e.g. "summary" or "customfield_10016".
+func (f CreateMetaField) ID() string {
+	if f.FieldID != "" {
+		return f.FieldID
+	}
+	return f.Key
+}
+
+// createMetaPage covers the paginated createmeta responses: Cloud names
+// its items issueTypes/fields, Server/DC names them values.
+type createMetaPage struct {
+	StartAt    int               `json:"startAt"`
+	MaxResults int               `json:"maxResults"`
+	Total      int               `json:"total"`
+	IsLast     bool              `json:"isLast"`
+	IssueTypes []IssueType       `json:"issueTypes"`
+	Fields     []CreateMetaField `json:"fields"`
+	Values     json.RawMessage   `json:"values"`
+}
+
+// legacyCreateMeta is the response of GET /issue/createmeta?expand=...,
+// the only createmeta API on Jira Server/DC before 8.4.
+type legacyCreateMeta struct {
+	Projects []struct {
+		Key        string `json:"key"`
+		IssueTypes []struct {
+			IssueType
+			Fields map[string]CreateMetaField `json:"fields"`
+		} `json:"issuetypes"`
+	} `json:"projects"`
+}
+
+// GetCreateIssueTypes returns the issue types that can be created in a
+// project.
+func (c *Client) GetCreateIssueTypes(projectKey string) ([]IssueType, error) {
+	return c.GetCreateIssueTypesContext(context.Background(), projectKey)
+}
+
+func (c *Client) GetCreateIssueTypesContext(ctx context.Context, projectKey string) ([]IssueType, error) {
+	endpoint := fmt.Sprintf("/rest/api/%s/issue/createmeta/%s/issuetypes", c.getAPIVersion(), url.PathEscape(projectKey))
+
+	var issueTypes []IssueType
+	err := c.forEachCreateMetaPage(ctx, endpoint, func(page *createMetaPage) (int, error) {
+		items := page.IssueTypes
+		if page.Values != nil {
+			if err := json.Unmarshal(page.Values, &items); err != nil {
+				return 0, fmt.Errorf("decoding issue types: %w", err)
+			}
+		}
+		issueTypes = append(issueTypes, items...)
+		return len(items), nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return c.legacyCreateIssueTypes(ctx, projectKey)
+	}
+	return issueTypes, err
+}
+
+// GetCreateFields returns the fields on the create screen of an issue type
+// in a project, including whether each is required and its allowed values.
+func (c *Client) GetCreateFields(projectKey, issueTypeID string) ([]CreateMetaField, error) {
+	return c.GetCreateFieldsContext(context.Background(), projectKey, issueTypeID)
+}
+
+func (c *Client) GetCreateFieldsContext(ctx context.Context, projectKey, issueTypeID string) ([]CreateMetaField, error) {
+	endpoint := fmt.Sprintf("/rest/api/%s/issue/createmeta/%s/issuetypes/%s",
+		c.getAPIVersion(), url.PathEscape(projectKey), url.PathEscape(issueTypeID))
+
+	var fields []CreateMetaField
+	err := c.forEachCreateMetaPage(ctx, endpoint, func(page *createMetaPage) (int, error) {
+		items := page.Fields
+		if page.Values != nil {
+			if err := json.Unmarshal(page.Values, &items); err != nil {
+				return 0, fmt.Errorf("decoding fields: %w", err)
+			}
+		}
+		fields = append(fields, items...)
+		return len(items), nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return c.legacyCreateFields(ctx, projectKey, issueTypeID)
+	}
+	return fields, err
+}
+
+// forEachCreateMetaPage pages through a createmeta endpoint. fn returns how
+// many items the page held so paging stops on short or empty pages.
+func (c *Client) forEachCreateMetaPage(ctx context.Context, endpoint string, fn func(*createMetaPage) (int, error)) error {
+	startAt := 0
+	for {
+		query := url.Values{}
+		query.Set("startAt", strconv.Itoa(startAt))
+		query.Set("maxResults", strconv.Itoa(createMetaPageSize))
+
+		resp, err := c.doRequest(ctx, "GET", endpoint+"?"+query.Encode(), nil)
+		if err != nil {
+			return err
+		}
+
+		var page createMetaPage
+		err = decodeJSON(resp, &page)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		n, err := fn(&page)
+		if err != nil {
+			return err
+		}
+
+		startAt += n
+		if n == 0 || page.IsLast || (page.Total > 0 && startAt >= page.Total) {
+			return nil
+		}
+	}
+}
+
+func (c *Client) legacyCreateMeta(ctx context.Context, projectKey, issueTypeID string) (*legacyCreateMeta, error) {
+	query := url.Values{}
+	query.Set("projectKeys", projectKey)
+	query.Set("expand", "projects.issuetypes.fields")
+	if issueTypeID != "" {
+		query.Set("issuetypeIds", issueTypeID)
+	}
+
+	endpoint := fmt.Sprintf("/rest/api/%s/issue/createmeta?%s", c.getAPIVersion(), query.Encode())
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var meta legacyCreateMeta
+	if err := decodeJSON(resp, &meta); err != nil {
+		return nil, err
+	}
+	if len(meta.Projects) == 0 {
+		return nil, fmt.Errorf("project %s: %w", projectKey, ErrNotFound)
+	}
+	return &meta, nil
+}
+
+func (c *Client) legacyCreateIssueTypes(ctx context.Context, projectKey string) ([]IssueType, error) {
+	meta, err := c.legacyCreateMeta(ctx, projectKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var issueTypes []IssueType
+	for _, issueType := range meta.Projects[0].IssueTypes {
+		issueTypes = append(issueTypes, issueType.IssueType)
+	}
+	return issueTypes, nil
+}
+
+func (c *Client) legacyCreateFields(ctx context.Context, projectKey, issueTypeID string) ([]CreateMetaField, error) {
+	meta, err := c.legacyCreateMeta(ctx, projectKey, issueTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []CreateMetaField
+	for _, issueType := range meta.Projects[0].IssueTypes {
+		if issueType.ID != issueTypeID {
+			continue
+		}
+		for id, field := range issueType.Fields {
+			if field.FieldID == "" && field.Key == "" {
+				field.FieldID = id
+			}
+			fields = append(fields, field)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].ID() < fields[j].ID() })
+	return fields, nil
+}
+
+// GetPriorities returns every priority defined on the instance.
+func (c *Client) GetPriorities() ([]Priority, error) {
+	return c.GetPrioritiesContext(context.Background())
+}
+
+func (c *Client) GetPrioritiesContext(ctx context.Context) ([]Priority, error) {
+	var priorities []Priority
+	if c.lookupCache(cache.KindMeta, metaPriorities, &priorities) {
+		return priorities, nil
+	}
+
+	endpoint := fmt.Sprintf("/rest/api/%s/priority", c.getAPIVersion())
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := decodeJSON(resp, &priorities); err != nil {
+		return nil, err
+	}
+	c.storeCache(cache.KindMeta, metaPriorities, priorities)
+	return priorities, nil
+}
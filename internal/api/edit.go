@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// IssueUpdate is the body of an edit request. Fields replaces values
+// outright; Update applies verbs such as add and remove, which is how
+// multi-valued fields like labels are changed without resending them all.
+type IssueUpdate struct {
+	Fields map[string]interface{}              `json:"fields,omitempty"`
+	Update map[string][]map[string]interface{} `json:"update,omitempty"`
+}
+
+// Set replaces a field's value; a nil value clears the field.
+func (u *IssueUpdate) Set(field string, value interface{}) {
+	if u.Fields == nil {
+		u.Fields = make(map[string]interface{})
+	}
+	u.Fields[field] = value
+}
+
+// Add appends value to a multi-valued field.
+func (u *IssueUpdate) Add(field string, value interface{}) {
+	u.addOperation(field, "add", value)
+}
+
+// Remove removes value from a multi-valued field.
+func (u *IssueUpdate) Remove(field string, value interface{}) {
+	u.addOperation(field, "remove", value)
+}
+
+func (u *IssueUpdate) addOperation(field, verb string, value interface{}) {
+	if u.Update == nil {
+		u.Update = make(map[string][]map[string]interface{})
+	}
+	u.Update[field] = append(u.Update[field], map[string]interface{}{verb: value})
+}
+
+// IsEmpty reports whether the update would change nothing.
+func (u *IssueUpdate) IsEmpty() bool {
+	return len(u.Fields) == 0 && len(u.Update) == 0
+}
+
+func (c *Client) EditIssue(issueKey string, update *IssueUpdate) error {
+	return c.EditIssueContext(context.Background(), issueKey, update)
+}
+
+func (c *Client) EditIssueContext(ctx context.Context, issueKey string, update *IssueUpdate) error {
+	requestBody, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("marshaling update: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s", c.getAPIVersion(), issueKey)
+	resp, err := c.doRequest(ctx, "PUT", endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sentinels for the broad classes of API failure. Match them with errors.Is,
+// e.g. errors.Is(err, api.ErrNotFound).
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("permission denied")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+)
+
+// Error is a non-2xx response from Jira. Jira reports failures as
+// {"errorMessages": [...], "errors": {"field": "message"}}; both are parsed
+// so callers can show field-specific validation messages.
+type Error struct {
+	StatusCode  int
+	Messages    []string          // errorMessages: general failures
+	FieldErrors map[string]string // errors: keyed by field ID
+	Body        string            // raw body, kept when it isn't Jira's error shape
+	RetryAfter  time.Duration     // how long a rate-limited or unavailable server asked us to wait
+}
+
+func (e *Error) Error() string {
+	var parts []string
+	parts = append(parts, e.Messages...)
+	for _, field := range e.Fields() {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, e.FieldErrors[field]))
+	}
+	if len(parts) == 0 {
+		if body := strings.TrimSpace(e.Body); body != "" && len(body) <= 200 {
+			parts = append(parts, body)
+		} else {
+			parts = append(parts, strings.ToLower(http.StatusText(e.StatusCode)))
+		}
+	}
+	message := fmt.Sprintf("HTTP %d: %s", e.StatusCode, strings.Join(parts, "; "))
+	if e.RetryAfter > 0 {
+		message += fmt.Sprintf(" (try again in %s)", e.RetryAfter.Round(time.Second))
+	}
+	return message
+}
+
+// Fields returns the names of fields with validation errors, sorted.
+func (e *Error) Fields() []string {
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+func newError(resp *http.Response) *Error {
+	body, _ := io.ReadAll(resp.Body)
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	if isRetryableStatus(resp.StatusCode) {
+		apiErr.RetryAfter, _ = rateLimitDelay(resp.Header, time.Now())
+	}
+
+	var parsed struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil &&
+		(len(parsed.ErrorMessages) > 0 || len(parsed.Errors) > 0) {
+		apiErr.Messages = parsed.ErrorMessages
+		apiErr.FieldErrors = parsed.Errors
+	} else {
+		apiErr.Body = string(body)
+	}
+	return apiErr
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+)
+
+// Field describes a system or custom field as returned by GET /field.
+type Field struct {
+	ID          string      `json:"id"`
+	Key         string      `json:"key"`
+	Name        string      `json:"name"`
+	Custom      bool        `json:"custom"`
+	ClauseNames []string    `json:"clauseNames"`
+	Schema      FieldSchema `json:"schema"`
+}
+
+// GetFields returns metadata for every field on the instance. The result is
+// cached for the lifetime of the client.
+func (c *Client) GetFields() ([]Field, error) {
+	return c.GetFieldsContext(context.Background())
+}
+
+func (c *Client) GetFieldsContext(ctx context.Context) ([]Field, error) {
+	c.fieldsMu.Lock()
+	defer c.fieldsMu.Unlock()
+	if c.fields != nil {
+		return c.fields, nil
+	}
+	var cached []Field
+	if c.lookupCache(cache.KindMeta, metaFields, &cached) {
+		c.fields = cached
+		return cached, nil
+	}
+
+	endpoint := fmt.Sprintf("/rest/api/%s/field", c.getAPIVersion())
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var fields []Field
+	if err := decodeJSON(resp, &fields); err != nil {
+		return nil, err
+	}
+	c.fields = fields
+	c.storeCache(cache.KindMeta, metaFields, fields)
+	return fields, nil
+}
+
+// LookupField finds a field by ID ("customfield_10016"), JQL clause name
+// ("cf[10016]") or display name ("Story Points"), ignoring case. A display
+// name shared by several fields is an error; the ID disambiguates.
+func (c *Client) LookupField(nameOrID string) (*Field, error) {
+	return c.LookupFieldContext(context.Background(), nameOrID)
+}
+
+func (c *Client) LookupFieldContext(ctx context.Context, nameOrID string) (*Field, error) {
+	fields, err := c.GetFieldsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading field metadata: %w", err)
+	}
+
+	nameOrID = strings.TrimSpace(nameOrID)
+	for i := range fields {
+		if strings.EqualFold(fields[i].ID, nameOrID) || strings.EqualFold(fields[i].Key, nameOrID) {
+			return &fields[i], nil
+		}
+	}
+
+	var matches []*Field
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, nameOrID) {
+			matches = append(matches, &fields[i])
+			continue
+		}
+		for _, clause := range fields[i].ClauseNames {
+			if strings.EqualFold(clause, nameOrID) {
+				matches = append(matches, &fields[i])
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown field %q", nameOrID)
+	case 1:
+		return matches[0], nil
+	}
+
+	var candidates []string
+	for _, field := range matches {
+		candidates = append(candidates, fmt.Sprintf("%s (%s)", field.Name, field.ID))
+	}
+	sort.Strings(candidates)
+	return nil, fmt.Errorf("field name %q is ambiguous: %s; use the field ID", nameOrID, strings.Join(candidates, ", "))
+}
+
+// ResolveFields turns name=value pairs typed by a user into an issue
+// "fields" object: names are resolved to field IDs and values are coerced
+// to the shape each field's schema expects.
+func (c *Client) ResolveFields(values map[string]string) (map[string]interface{}, error) {
+	return c.ResolveFieldsContext(context.Background(), values)
+}
+
+func (c *Client) ResolveFieldsContext(ctx context.Context, values map[string]string) (map[string]interface{}, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	resolved := make(map[string]interface{}, len(values))
+	for name, input := range values {
+		field, err := c.LookupFieldContext(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := c.FieldValueContext(ctx, field.Schema, input)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		resolved[field.ID] = value
+	}
+	return resolved, nil
+}
+
+// FieldValue converts text typed by a user into the JSON value a field of
+// the given schema expects. Numbers become numbers, options {"value": ...},
+// users {"accountId": ...} (or {"name": ...} on Server/DC, where "me" is
+// also resolved), dates are checked, and array fields take comma-separated
+// items. For fields that aren't plain text, a JSON object or array is passed
+// through untouched.
+func (c *Client) FieldValue(schema FieldSchema, input string) (interface{}, error) {
+	return c.FieldValueContext(context.Background(), schema, input)
+}
+
+func (c *Client) FieldValueContext(ctx context.Context, schema FieldSchema, input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if raw, ok := rawJSONValue(schema, input); ok {
+		return raw, nil
+	}
+
+	if schema.Type != "array" {
+		return c.scalarFieldValue(ctx, schema.Type, schema.Custom, input)
+	}
+	if schema.Items == "string" && input == "" {
+		return []interface{}{}, nil
+	}
+
+	items := []interface{}{}
+	for _, item := range strings.Split(input, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		value, err := c.scalarFieldValue(ctx, schema.Items, "", item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	return items, nil
+}
+
+func (c *Client) scalarFieldValue(ctx context.Context, valueType, custom, input string) (interface{}, error) {
+	switch valueType {
+	case "number":
+		n, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", input)
+		}
+		return n, nil
+
+	case "date":
+		if _, err := time.Parse("2006-01-02", input); err != nil {
+			return nil, fmt.Errorf("%q is not a date (YYYY-MM-DD)", input)
+		}
+		return input, nil
+
+	case "datetime":
+		for _, layout := range []string{"2006-01-02T15:04:05.000-0700", time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+			if t, err := time.ParseInLocation(layout, input, time.Local); err == nil {
+				return t.Format("2006-01-02T15:04:05.000-0700"), nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not a date and time (YYYY-MM-DD HH:MM)", input)
+
+	case "user":
+		user, err := c.resolveAssignee(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		if c.AuthType == "pat" {
+			return map[string]interface{}{"name": user}, nil
+		}
+		return map[string]interface{}{"accountId": user}, nil
+
+	case "option":
+		return map[string]interface{}{"value": input}, nil
+
+	case "option-with-child":
+		// "Parent > Child" selects a cascading option.
+		parent, child, ok := strings.Cut(input, ">")
+		value := map[string]interface{}{"value": strings.TrimSpace(parent)}
+		if ok {
+			value["child"] = map[string]interface{}{"value": strings.TrimSpace(child)}
+		}
+		return value, nil
+
+	case "priority", "version", "component", "issuetype", "resolution", "securitylevel":
+		return map[string]interface{}{"name": input}, nil
+
+	case "issuelink", "issuelinks":
+		return map[string]interface{}{"key": input}, nil
+
+	case "string":
+		if strings.HasSuffix(custom, ":textarea") {
+			return c.RichText(input), nil
+		}
+		return input, nil
+	}
+
+	return input, nil
+}
+
+// rawJSONValue decodes input when it is a JSON object or array, which lets
+// users send any value shape Jira accepts. Text fields always take input as
+// text, and anything that doesn't parse is converted as usual, so
+// "[beta] fix" stays a string.
+func rawJSONValue(schema FieldSchema, input string) (interface{}, bool) {
+	if schema.Type == "string" {
+		return nil, false
+	}
+	if !strings.HasPrefix(input, "{") && !strings.HasPrefix(input, "[") {
+		return nil, false
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(input), &decoded); err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
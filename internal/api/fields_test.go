@@ -0,0 +1,79 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestFieldValue(t *testing.T) {
+	client := NewClient("https://example.atlassian.net", "a@example.com", "token")
+
+	text := FieldSchema{Type: "string"}
+	tests := []struct {
+		name   string
+		schema FieldSchema
+		input  string
+		want   interface{}
+	}{
+		{"text with brackets", text, "[beta] fix", "[beta] fix"},
+		{"text that is valid JSON", text, `{"a": 1}`, `{"a": 1}`},
+		{"number", FieldSchema{Type: "number"}, "3", 3.0},
+		{"option", FieldSchema{Type: "option"}, "High", map[string]interface{}{"value": "High"}},
+		{"raw JSON option", FieldSchema{Type: "option"}, `{"id": "10001"}`, map[string]interface{}{"id": "10001"}},
+		{"labels", FieldSchema{Type: "array", Items: "string"}, "a, b", []interface{}{"a", "b"}},
+		{"labels as JSON", FieldSchema{Type: "array", Items: "string"}, `["a", "b"]`, []interface{}{"a", "b"}},
+		{"labels that aren't JSON", FieldSchema{Type: "array", Items: "string"}, "[beta], fix", []interface{}{"[beta]", "fix"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.FieldValue(tt.schema, tt.input)
+			if err != nil {
+				t.Fatalf("FieldValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserFieldValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/myself":
+			fmt.Fprint(w, `{"name":"jdoe","key":"JIRAUSER1","displayName":"Jane Doe"}`)
+		case "/rest/api/3/myself":
+			fmt.Fprint(w, `{"accountId":"5b10a2844c20165700ede21g","displayName":"Jane Doe"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	user := FieldSchema{Type: "user"}
+	tests := []struct {
+		name     string
+		authType string
+		input    string
+		want     map[string]interface{}
+	}{
+		{"me on Server/DC", "pat", "@me", map[string]interface{}{"name": "jdoe"}},
+		{"username on Server/DC", "pat", "asmith", map[string]interface{}{"name": "asmith"}},
+		{"me on Cloud", "basic", "me", map[string]interface{}{"accountId": "5b10a2844c20165700ede21g"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithAuthType(server.URL, "a@example.com", "token", tt.authType)
+			got, err := client.FieldValue(user, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,44 @@
 package api
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+)
+
+// maxSearchPageSize is the largest page Jira honours for search requests;
+// bigger maxResults values are silently clamped by the server.
+const maxSearchPageSize = 100
+
+// ErrStopSearch can be returned from a ForEachIssue callback to stop
+// paginating early without ForEachIssue reporting an error.
+var ErrStopSearch = errors.New("stop search")
 
 func (c *Client) GetIssue(issueKey string) (*Issue, error) {
+	return c.GetIssueContext(context.Background(), issueKey)
+}
+
+func (c *Client) GetIssueContext(ctx context.Context, issueKey string) (*Issue, error) {
+	var issue Issue
+	if c.lookupCache(cache.KindIssue, strings.ToUpper(issueKey), &issue) {
+		return &issue, nil
+	}
+	return c.GetFreshIssueContext(ctx, issueKey)
+}
+
+// GetFreshIssue fetches an issue from Jira even when the cache holds it,
+// for callers about to change the issue based on what they read.
+func (c *Client) GetFreshIssue(issueKey string) (*Issue, error) {
+	return c.GetFreshIssueContext(context.Background(), issueKey)
+}
+
+func (c *Client) GetFreshIssueContext(ctx context.Context, issueKey string) (*Issue, error) {
+	var issue Issue
 	apiVersion := c.getAPIVersion()
 	var endpoint string
 
@@ -14,30 +50,132 @@ func (c *Client) GetIssue(issueKey string) (*Issue, error) {
 		endpoint = fmt.Sprintf("/rest/api/%s/issue/%s", apiVersion, issueKey)
 	}
 
-	resp, err := c.doRequest("GET", endpoint, nil)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var issue Issue
-	return &issue, decodeJSON(resp, &issue)
+	if err := decodeJSON(resp, &issue); err != nil {
+		return &issue, err
+	}
+	c.cacheIssues([]Issue{issue})
+	return &issue, nil
 }
 
+// SearchIssues returns up to maxResults issues matching jql, following
+// pagination as needed. A maxResults of 0 or less fetches every match.
 func (c *Client) SearchIssues(jql string, maxResults int) (*SearchResults, error) {
+	return c.SearchIssuesContext(context.Background(), jql, maxResults)
+}
+
+func (c *Client) SearchIssuesContext(ctx context.Context, jql string, maxResults int) (*SearchResults, error) {
+	if results, ok := c.lookupSearch(jql, maxResults); ok {
+		return results, nil
+	}
+
+	results := &SearchResults{}
+	err := c.forEachPage(ctx, jql, maxResults, func(page *SearchResults) {
+		results.Total = page.Total
+	}, func(issue Issue) error {
+		results.Issues = append(results.Issues, issue)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	results.MaxResults = len(results.Issues)
+	c.storeSearch(jql, maxResults, results)
+	return results, nil
+}
+
+// ForEachIssue streams issues matching jql to fn one at a time, requesting
+// further pages only as they are needed. A limit of 0 or less streams every
+// match. Returning ErrStopSearch from fn ends the search cleanly; any other
+// error is returned to the caller.
+func (c *Client) ForEachIssue(jql string, limit int, fn func(Issue) error) error {
+	return c.ForEachIssueContext(context.Background(), jql, limit, fn)
+}
+
+func (c *Client) ForEachIssueContext(ctx context.Context, jql string, limit int, fn func(Issue) error) error {
+	return c.forEachPage(ctx, jql, limit, nil, fn)
+}
+
+func (c *Client) forEachPage(ctx context.Context, jql string, limit int, onPage func(*SearchResults), fn func(Issue) error) error {
+	startAt := 0
+	pageToken := ""
+	seen := 0
+
+	for {
+		pageSize := maxSearchPageSize
+		if limit > 0 && limit-seen < pageSize {
+			pageSize = limit - seen
+		}
+
+		page, err := c.searchPage(ctx, jql, startAt, pageToken, pageSize)
+		if err != nil {
+			return err
+		}
+		if onPage != nil {
+			onPage(page)
+		}
+
+		for _, issue := range page.Issues {
+			if err := fn(issue); err != nil {
+				if errors.Is(err, ErrStopSearch) {
+					return nil
+				}
+				return err
+			}
+			seen++
+			if limit > 0 && seen >= limit {
+				return nil
+			}
+		}
+
+		if len(page.Issues) == 0 {
+			return nil
+		}
+
+		// Jira Cloud's /search/jql paginates with opaque tokens, while
+		// Server/DC's /search uses numeric offsets.
+		if c.AuthType == "pat" {
+			startAt = page.StartAt + len(page.Issues)
+			if page.Total > 0 && startAt >= page.Total {
+				return nil
+			}
+		} else {
+			if page.IsLast || page.NextPageToken == "" {
+				return nil
+			}
+			pageToken = page.NextPageToken
+		}
+	}
+}
+
+func (c *Client) searchPage(ctx context.Context, jql string, startAt int, pageToken string, maxResults int) (*SearchResults, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("maxResults", strconv.Itoa(maxResults))
+
 	var endpoint string
 
 	// Jira Cloud (basic auth) uses API v3 with /search/jql endpoint
 	// Jira Server/DC (PAT) uses API v2 with /search endpoint
 	if c.AuthType == "pat" {
-		endpoint = fmt.Sprintf("/rest/api/2/search?jql=%s&maxResults=%d", jql, maxResults)
+		params.Set("startAt", strconv.Itoa(startAt))
+		endpoint = "/rest/api/2/search?" + params.Encode()
 	} else {
 		// Jira Cloud requires /search/jql endpoint (new as of 2024)
 		// Must explicitly request fields (default is only "id")
-		endpoint = fmt.Sprintf("/rest/api/3/search/jql?jql=%s&maxResults=%d&fields=*navigable", jql, maxResults)
+		params.Set("fields", "*navigable")
+		if pageToken != "" {
+			params.Set("nextPageToken", pageToken)
+		}
+		endpoint = "/rest/api/3/search/jql?" + params.Encode()
 	}
 
-	resp, err := c.doRequest("GET", endpoint, nil)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+)
+
+// LinkRelation is a link type read in one direction: "blocks" is the
+// outward side of Blocks, "is blocked by" the inward side.
+type LinkRelation struct {
+	Type   IssueLinkType
+	Inward bool
+}
+
+// Description returns the relation as Jira words it, e.g. "is blocked by".
+func (r LinkRelation) Description() string {
+	if r.Inward {
+		return r.Type.Inward
+	}
+	return r.Type.Outward
+}
+
+func (c *Client) GetIssueLinkTypes() ([]IssueLinkType, error) {
+	return c.GetIssueLinkTypesContext(context.Background())
+}
+
+func (c *Client) GetIssueLinkTypesContext(ctx context.Context) ([]IssueLinkType, error) {
+	var linkTypes []IssueLinkType
+	if c.lookupCache(cache.KindMeta, metaLinkTypes, &linkTypes) {
+		return linkTypes, nil
+	}
+
+	endpoint := fmt.Sprintf("/rest/api/%s/issueLinkType", c.getAPIVersion())
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		IssueLinkTypes []IssueLinkType `json:"issueLinkTypes"`
+	}
+	if err := decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+	c.storeCache(cache.KindMeta, metaLinkTypes, result.IssueLinkTypes)
+	return result.IssueLinkTypes, nil
+}
+
+func (c *Client) FindLinkRelation(phrase string) (*LinkRelation, error) {
+	return c.FindLinkRelationContext(context.Background(), phrase)
+}
+
+// FindLinkRelationContext matches a phrase such as "blocks", "blocked by",
+// "dup" or "relates" against the link types' inward and outward
+// descriptions and names. Exact matches win over prefixes, and prefixes
+// over substrings; several matches at the same level are an error.
+func (c *Client) FindLinkRelationContext(ctx context.Context, phrase string) (*LinkRelation, error) {
+	linkTypes, err := c.GetIssueLinkTypesContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching link types: %w", err)
+	}
+
+	input := normalizeLinkPhrase(phrase)
+	var candidates []LinkRelation
+	for _, linkType := range linkTypes {
+		candidates = append(candidates, LinkRelation{Type: linkType}, LinkRelation{Type: linkType, Inward: true})
+	}
+
+	matchers := []func(string) bool{
+		func(s string) bool { return s == input },
+		func(s string) bool { return strings.HasPrefix(s, input) },
+		func(s string) bool { return strings.Contains(s, input) },
+	}
+	for _, matches := range matchers {
+		var found []LinkRelation
+		for _, candidate := range candidates {
+			names := []string{normalizeLinkPhrase(candidate.Description())}
+			if !candidate.Inward {
+				names = append(names, normalizeLinkPhrase(candidate.Type.Name))
+			}
+			for _, name := range names {
+				if input != "" && matches(name) {
+					found = appendRelation(found, candidate)
+					break
+				}
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return &found[0], nil
+		}
+		return nil, fmt.Errorf("link type '%s' is ambiguous: %s", phrase, relationList(found))
+	}
+
+	return nil, fmt.Errorf("no matching link type found for '%s'. Available: %s", phrase, relationList(candidates))
+}
+
+// appendRelation skips a relation whose wording duplicates one already
+// found, as with symmetric types like "relates to".
+func appendRelation(found []LinkRelation, relation LinkRelation) []LinkRelation {
+	for _, f := range found {
+		if f.Type.ID == relation.Type.ID && f.Description() == relation.Description() {
+			return found
+		}
+	}
+	return append(found, relation)
+}
+
+func normalizeLinkPhrase(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(s)
+}
+
+func relationList(relations []LinkRelation) string {
+	seen := map[string]bool{}
+	var names []string
+	for _, relation := range relations {
+		if description := relation.Description(); !seen[description] {
+			seen[description] = true
+			names = append(names, description)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func (c *Client) LinkIssues(fromKey, phrase, toKey string) (*LinkRelation, error) {
+	return c.LinkIssuesContext(context.Background(), fromKey, phrase, toKey)
+}
+
+// LinkIssuesContext links two issues so that "fromKey <phrase> toKey" reads
+// true, e.g. LinkIssues("PROJ-1", "blocks", "PROJ-2"). It returns the
+// relation the phrase matched.
+func (c *Client) LinkIssuesContext(ctx context.Context, fromKey, phrase, toKey string) (*LinkRelation, error) {
+	relation, err := c.FindLinkRelationContext(ctx, phrase)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jira reads a link as "inwardIssue <outward description> outwardIssue",
+	// so an inward phrase swaps the two ends.
+	inward, outward := fromKey, toKey
+	if relation.Inward {
+		inward, outward = toKey, fromKey
+	}
+
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"type":         map[string]string{"name": relation.Type.Name},
+		"inwardIssue":  map[string]string{"key": inward},
+		"outwardIssue": map[string]string{"key": outward},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling link: %w", err)
+	}
+
+	c.forgetIssues(fromKey, toKey)
+	endpoint := fmt.Sprintf("/rest/api/%s/issueLink", c.getAPIVersion())
+	resp, err := c.doRequest(ctx, "POST", endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return relation, checkResponse(resp)
+}
+
+func (c *Client) DeleteIssueLink(linkID string) error {
+	return c.DeleteIssueLinkContext(context.Background(), linkID)
+}
+
+func (c *Client) DeleteIssueLinkContext(ctx context.Context, linkID string) error {
+	endpoint := fmt.Sprintf("/rest/api/%s/issueLink/%s", c.getAPIVersion(), linkID)
+	if c.Cache != nil {
+		c.forgetLinkedIssues(ctx, endpoint)
+	}
+	resp, err := c.doRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+// forgetLinkedIssues drops both ends of the link at endpoint from the
+// cache, since deleting it only names the link. If the link can't be read,
+// every cached issue is dropped instead.
+func (c *Client) forgetLinkedIssues(ctx context.Context, endpoint string) {
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err == nil {
+		defer resp.Body.Close()
+		var link IssueLink
+		if err = decodeJSON(resp, &link); err == nil {
+			for _, end := range []*LinkedIssue{link.InwardIssue, link.OutwardIssue} {
+				if end != nil {
+					c.forgetIssues(end.Key)
+				}
+			}
+			return
+		}
+	}
+	c.Cache.DeleteKind(cache.KindIssue)
+}
+
+func (c *Client) UnlinkIssues(fromKey, toKey, phrase string) ([]IssueLink, error) {
+	return c.UnlinkIssuesContext(context.Background(), fromKey, toKey, phrase)
+}
+
+// UnlinkIssuesContext deletes the links between two issues and returns
+// them. If phrase is not empty only links matching that relation, read from
+// fromKey's side, are removed.
+func (c *Client) UnlinkIssuesContext(ctx context.Context, fromKey, toKey, phrase string) ([]IssueLink, error) {
+	var relation *LinkRelation
+	if phrase != "" {
+		var err error
+		if relation, err = c.FindLinkRelationContext(ctx, phrase); err != nil {
+			return nil, err
+		}
+	}
+
+	issue, err := c.GetFreshIssueContext(ctx, fromKey)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", fromKey, err)
+	}
+
+	var removed []IssueLink
+	for _, link := range issue.Fields.IssueLinks {
+		description, other := link.Relation()
+		if other == nil || !strings.EqualFold(other.Key, toKey) {
+			continue
+		}
+		if relation != nil && (link.Type.ID != relation.Type.ID || description != relation.Description()) {
+			continue
+		}
+		if err := c.DeleteIssueLinkContext(ctx, link.ID); err != nil {
+			return removed, err
+		}
+		removed = append(removed, link)
+	}
+
+	if len(removed) == 0 {
+		what := "no links"
+		if relation != nil {
+			what = fmt.Sprintf("no '%s' link", relation.Description())
+		}
+		return nil, fmt.Errorf("%s from %s to %s: %w", what, fromKey, toKey, ErrNotFound)
+	}
+	return removed, nil
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+)
+
+// GetProjects returns the projects the user can see.
+func (c *Client) GetProjects() ([]Project, error) {
+	return c.GetProjectsContext(context.Background())
+}
+
+func (c *Client) GetProjectsContext(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	if c.lookupCache(cache.KindMeta, metaProjects, &projects) {
+		return projects, nil
+	}
+
+	endpoint := fmt.Sprintf("/rest/api/%s/project", c.getAPIVersion())
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := decodeJSON(resp, &projects); err != nil {
+		return nil, err
+	}
+	c.storeCache(cache.KindMeta, metaProjects, projects)
+	return projects, nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how doRequest retries throttled or failed requests.
+type RetryPolicy struct {
+	MaxRetries int           // retries after the first attempt; 0 disables retrying
+	BaseDelay  time.Duration // delay before the first retry, doubled on each attempt
+	MaxDelay   time.Duration // upper bound for any single wait; a longer Retry-After gives up instead
+	RetryPOST  bool          // POST is not idempotent, so it is only retried when opted in
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// canRetryMethod reports whether a request with this method may be sent again
+// without risking a duplicate side effect.
+func (p RetryPolicy) canRetryMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryPOST
+	}
+	return false
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before retry number attempt (starting at 0):
+// exponential growth with "equal jitter", so concurrent scripts hitting the
+// same rate limit don't retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << attempt
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// retryDelay picks the wait before the next attempt, preferring what the
+// server told us via Retry-After or X-RateLimit-Reset over our own backoff.
+// The server's wait is honoured in full, as retrying sooner only hits the
+// limit again; when it's longer than MaxDelay, ok is false and the request
+// gives up instead.
+func (p RetryPolicy) retryDelay(attempt int, resp *http.Response) (delay time.Duration, ok bool) {
+	delay = p.backoff(attempt)
+	if resp != nil {
+		if serverDelay, found := rateLimitDelay(resp.Header, time.Now()); found {
+			if p.MaxDelay > 0 && serverDelay > p.MaxDelay {
+				return 0, false
+			}
+			if serverDelay > delay {
+				delay = serverDelay
+			}
+		}
+	}
+	return delay, true
+}
+
+// rateLimitDelay reads Retry-After (seconds or HTTP date) and, failing that,
+// Jira Cloud's X-RateLimit-Reset (ISO 8601 timestamp) when no requests remain.
+func rateLimitDelay(header http.Header, now time.Time) (time.Duration, bool) {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return nonNegative(at.Sub(now)), true
+		}
+	}
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if value := header.Get("X-RateLimit-Reset"); value != "" {
+			for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+				if at, err := time.Parse(layout, value); err == nil {
+					return nonNegative(at.Sub(now)), true
+				}
+			}
+			if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return nonNegative(time.Unix(epoch, 0).Sub(now)), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d, returning early with ctx.Err() if ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer answers with the given statuses in turn, then 200. headers
+// are sent with every failure.
+func flakyServer(t *testing.T, headers map[string]string, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&requests, 1))
+		if n <= len(statuses) {
+			for key, value := range headers {
+				w.Header().Set(key, value)
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func fastRetryClient(url string) *Client {
+	client := NewClient(url, "a@example.com", "token")
+	client.Retry = RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	return client
+}
+
+func TestDoRequestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		retryPOST    bool
+		statuses     []int
+		wantStatus   int
+		wantRequests int32
+	}{
+		{"rate limited then ok", "GET", false, []int{429}, 200, 2},
+		{"unavailable three times", "GET", false, []int{503, 502, 504}, 200, 4},
+		{"gives up after MaxRetries", "GET", false, []int{503, 503, 503, 503, 503}, 503, 4},
+		{"client errors aren't retried", "GET", false, []int{404}, 404, 1},
+		{"POST isn't retried", "POST", false, []int{503}, 503, 1},
+		{"POST is retried when opted in", "POST", true, []int{503}, 200, 2},
+		{"PUT is retried", "PUT", false, []int{429}, 200, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := flakyServer(t, nil, tt.statuses...)
+			client := fastRetryClient(server.URL)
+			client.Retry.RetryPOST = tt.retryPOST
+
+			resp, err := client.doRequest(context.Background(), tt.method, "/rest/api/3/myself", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := atomic.LoadInt32(requests); got != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestDoRequestHonoursRetryAfter(t *testing.T) {
+	server, requests := flakyServer(t, map[string]string{"Retry-After": "1"}, 429)
+	client := fastRetryClient(server.URL)
+	client.Retry.MaxDelay = 2 * time.Second
+
+	start := time.Now()
+	resp, err := client.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, sooner than Retry-After asked for", elapsed)
+	}
+	if resp.StatusCode != 200 || atomic.LoadInt32(requests) != 2 {
+		t.Errorf("status %d after %d requests, want 200 after 2", resp.StatusCode, atomic.LoadInt32(requests))
+	}
+}
+
+func TestDoRequestGivesUpOnLongRetryAfter(t *testing.T) {
+	server, requests := flakyServer(t, map[string]string{"Retry-After": "120"}, 429)
+	client := fastRetryClient(server.URL)
+
+	start := time.Now()
+	resp, err := client.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if time.Since(start) > time.Second || atomic.LoadInt32(requests) != 1 {
+		t.Fatalf("waited %s and sent %d requests, want to give up at once", time.Since(start), atomic.LoadInt32(requests))
+	}
+
+	err = checkResponse(resp)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrRateLimited) || apiErr.RetryAfter != 2*time.Minute {
+		t.Errorf("error = %v, want a rate limit error asking for 2m", err)
+	}
+}
+
+func TestDoRequestStopsWaitingWhenCancelled(t *testing.T) {
+	server, _ := flakyServer(t, map[string]string{"Retry-After": "1"}, 429)
+	client := fastRetryClient(server.URL)
+	client.Retry.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.doRequest(ctx, "GET", "/rest/api/3/myself", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("doRequest() = %v, want the context's error", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 50 * time.Millisecond, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond, 800 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second}, // capped by MaxDelay
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := policy.backoff(tt.attempt); got < tt.min || got > tt.max {
+				t.Errorf("backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+		wantOK  bool
+	}{
+		{"seconds", map[string]string{"Retry-After": "7"}, 7 * time.Second, true},
+		{"HTTP date", map[string]string{"Retry-After": "Mon, 02 Jun 2025 12:00:30 GMT"}, 30 * time.Second, true},
+		{"date in the past", map[string]string{"Retry-After": "Mon, 02 Jun 2025 11:00:00 GMT"}, 0, true},
+		{"rate limit reset", map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "2025-06-02T12:01:00Z"}, time.Minute, true},
+		{"reset with requests left", map[string]string{"X-RateLimit-Remaining": "5", "X-RateLimit-Reset": "2025-06-02T12:01:00Z"}, 0, false},
+		{"nothing", nil, 0, false},
+		{"garbage", map[string]string{"Retry-After": "soon"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tt.headers {
+				header.Set(key, value)
+			}
+			got, ok := rateLimitDelay(header, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("rateLimitDelay() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -2,13 +2,18 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 )
 
 func (c *Client) UpdateIssueStatus(issueKey, status string) error {
-	transitions, err := c.GetTransitions(issueKey)
+	return c.UpdateIssueStatusContext(context.Background(), issueKey, status)
+}
+
+func (c *Client) UpdateIssueStatusContext(ctx context.Context, issueKey, status string) error {
+	transitions, err := c.GetTransitionsContext(ctx, issueKey)
 	if err != nil {
 		return fmt.Errorf("fetching transitions: %w", err)
 	}
@@ -61,17 +66,27 @@ func (c *Client) UpdateIssueStatus(issueKey, status string) error {
 			status, strings.Join(availableStatuses, ", "))
 	}
 
+	return c.TransitionIssueContext(ctx, issueKey, matchedTransition.ID)
+}
+
+func (c *Client) TransitionIssue(issueKey, transitionID string) error {
+	return c.TransitionIssueContext(context.Background(), issueKey, transitionID)
+}
+
+// TransitionIssueContext performs one of the transitions GetTransitions
+// returned for the issue.
+func (c *Client) TransitionIssueContext(ctx context.Context, issueKey, transitionID string) error {
 	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s/transitions", c.getAPIVersion(), issueKey)
 	requestBody, err := json.Marshal(map[string]interface{}{
 		"transition": map[string]string{
-			"id": matchedTransition.ID,
+			"id": transitionID,
 		},
 	})
 	if err != nil {
 		return fmt.Errorf("marshaling transition: %w", err)
 	}
 
-	resp, err := c.doRequest("POST", endpoint, bytes.NewReader(requestBody))
+	resp, err := c.doRequest(ctx, "POST", endpoint, bytes.NewReader(requestBody))
 	if err != nil {
 		return err
 	}
@@ -81,8 +96,12 @@ func (c *Client) UpdateIssueStatus(issueKey, status string) error {
 }
 
 func (c *Client) GetTransitions(issueKey string) (*TransitionResponse, error) {
+	return c.GetTransitionsContext(context.Background(), issueKey)
+}
+
+func (c *Client) GetTransitionsContext(ctx context.Context, issueKey string) (*TransitionResponse, error) {
 	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s/transitions", c.getAPIVersion(), issueKey)
-	resp, err := c.doRequest("GET", endpoint, nil)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"encoding/json"
+	"reflect"
 	"strings"
 	"time"
 )
@@ -39,34 +41,170 @@ func (jt *JiraTime) UnmarshalJSON(b []byte) error {
 }
 
 type Issue struct {
-	ID     string      `json:"id"`
-	Key    string      `json:"key"`
-	Fields IssueFields `json:"fields"`
+	ID        string      `json:"id"`
+	Key       string      `json:"key"`
+	Fields    IssueFields `json:"fields"`
+	Changelog *Changelog  `json:"changelog,omitempty"`
+}
+
+// Changelog is an issue's edit history, returned with expand=changelog.
+type Changelog struct {
+	Histories []ChangelogHistory `json:"histories"`
+}
+
+type ChangelogHistory struct {
+	ID      string          `json:"id"`
+	Author  *User           `json:"author"`
+	Created JiraTime        `json:"created"`
+	Items   []ChangelogItem `json:"items"`
+}
+
+// ChangelogItem is one field change. From/To hold IDs, FromString and
+// ToString the display values.
+type ChangelogItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
 }
 
 type IssueFields struct {
-	Summary     string      `json:"summary"`
-	Description interface{} `json:"description"`
-	IssueType   IssueType   `json:"issuetype"`
-	Status      Status      `json:"status"`
-	Priority    Priority    `json:"priority"`
-	Assignee    *User       `json:"assignee"`
-	Reporter    *User       `json:"reporter"`
-	Created     JiraTime    `json:"created"`
-	Updated     JiraTime    `json:"updated"`
-	Project     Project     `json:"project"`
+	Summary     string       `json:"summary"`
+	Description interface{}  `json:"description"`
+	IssueType   IssueType    `json:"issuetype"`
+	Status      Status       `json:"status"`
+	Priority    Priority     `json:"priority"`
+	Assignee    *User        `json:"assignee"`
+	Reporter    *User        `json:"reporter"`
+	Created     JiraTime     `json:"created"`
+	Updated     JiraTime     `json:"updated"`
+	Project     Project      `json:"project"`
+	IssueLinks  []IssueLink  `json:"issuelinks"`
+	Attachments []Attachment `json:"attachment"`
+
+	// Other holds every field not modelled above (custom fields, labels,
+	// components, ...) as raw JSON keyed by field ID. Null values are
+	// dropped.
+	Other map[string]json.RawMessage `json:"-"`
+}
+
+// issueFieldNames lists the JSON names of the typed IssueFields members.
+var issueFieldNames = jsonFieldNames(reflect.TypeOf(IssueFields{}))
+
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type plain IssueFields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	f.Other = nil
+	for id, raw := range all {
+		if issueFieldNames[id] || string(raw) == "null" {
+			continue
+		}
+		if f.Other == nil {
+			f.Other = make(map[string]json.RawMessage)
+		}
+		f.Other[id] = raw
+	}
+	return nil
+}
+
+func (f IssueFields) MarshalJSON() ([]byte, error) {
+	type plain IssueFields
+	raw, err := json.Marshal(plain(f))
+	if err != nil || len(f.Other) == 0 {
+		return raw, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return nil, err
+	}
+	for id, value := range f.Other {
+		if _, ok := merged[id]; !ok {
+			merged[id] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// IssueLinkType is a kind of relationship, described from each side: for
+// "Blocks", Outward is "blocks" and Inward is "is blocked by".
+type IssueLinkType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// IssueLink is a link as listed on an issue. Exactly one of InwardIssue and
+// OutwardIssue is set: the issue at the other end.
+type IssueLink struct {
+	ID           string        `json:"id"`
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  *LinkedIssue  `json:"inwardIssue,omitempty"`
+	OutwardIssue *LinkedIssue  `json:"outwardIssue,omitempty"`
+}
+
+// Relation returns how the issue holding the link relates to the other
+// end, e.g. "blocks", and the issue at that end.
+func (l IssueLink) Relation() (string, *LinkedIssue) {
+	if l.OutwardIssue != nil {
+		return l.Type.Outward, l.OutwardIssue
+	}
+	return l.Type.Inward, l.InwardIssue
+}
+
+type LinkedIssue struct {
+	ID     string            `json:"id"`
+	Key    string            `json:"key"`
+	Fields LinkedIssueFields `json:"fields"`
+}
+
+type LinkedIssueFields struct {
+	Summary   string    `json:"summary"`
+	Status    Status    `json:"status"`
+	Priority  Priority  `json:"priority"`
+	IssueType IssueType `json:"issuetype"`
 }
 
 type IssueType struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Subtask     bool   `json:"subtask"`
 }
 
 type Status struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	StatusCategory *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// StatusCategory groups statuses into "new" (to do), "indeterminate" (in
+// progress) and "done".
+type StatusCategory struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
 }
 
 type Priority struct {
@@ -76,11 +214,27 @@ type Priority struct {
 
 type User struct {
 	AccountID    string `json:"accountId"`
+	Name         string `json:"name,omitempty"` // Server/DC username
+	Key          string `json:"key,omitempty"`  // Server/DC user key
 	DisplayName  string `json:"displayName"`
 	EmailAddress string `json:"emailAddress"`
 	Active       bool   `json:"active"`
 }
 
+// SameAs reports whether u and other are the same account, comparing
+// account IDs on Cloud and user keys or usernames on Server/DC.
+func (u *User) SameAs(other *User) bool {
+	switch {
+	case u == nil || other == nil:
+		return false
+	case u.AccountID != "" || other.AccountID != "":
+		return u.AccountID == other.AccountID
+	case u.Key != "" && other.Key != "":
+		return u.Key == other.Key
+	}
+	return u.Name != "" && u.Name == other.Name
+}
+
 type Project struct {
 	ID   string `json:"id"`
 	Key  string `json:"key"`
@@ -88,11 +242,37 @@ type Project struct {
 }
 
 type SearchResults struct {
-	Expand     string  `json:"expand"`
-	StartAt    int     `json:"startAt"`
-	MaxResults int     `json:"maxResults"`
-	Total      int     `json:"total"`
-	Issues     []Issue `json:"issues"`
+	Expand        string  `json:"expand"`
+	StartAt       int     `json:"startAt"`
+	MaxResults    int     `json:"maxResults"`
+	Total         int     `json:"total"`
+	Issues        []Issue `json:"issues"`
+	NextPageToken string  `json:"nextPageToken"` // Jira Cloud /search/jql only
+	IsLast        bool    `json:"isLast"`        // Jira Cloud /search/jql only
+}
+
+// Attachment is a file attached to an issue. Content is the download URL
+// Jira reports, which may point at a different host than the API.
+type Attachment struct {
+	ID       string   `json:"id"`
+	Filename string   `json:"filename"`
+	Author   *User    `json:"author"`
+	Created  JiraTime `json:"created"`
+	Size     int64    `json:"size"`
+	MimeType string   `json:"mimeType"`
+	Content  string   `json:"content"`
+}
+
+type Worklog struct {
+	ID               string      `json:"id"`
+	IssueID          string      `json:"issueId"`
+	Author           *User       `json:"author"`
+	Comment          interface{} `json:"comment"`
+	Started          JiraTime    `json:"started"`
+	TimeSpent        string      `json:"timeSpent"`
+	TimeSpentSeconds int         `json:"timeSpentSeconds"`
+	Created          JiraTime    `json:"created"`
+	Updated          JiraTime    `json:"updated"`
 }
 
 type Comment struct {
@@ -161,6 +341,30 @@ type CreateIssueFields struct {
 	IssueType   IssueTypeRef   `json:"issuetype"`
 	Priority    *PriorityRef   `json:"priority,omitempty"`
 	Assignee    *AssigneeRef   `json:"assignee,omitempty"`
+	Labels      []string       `json:"labels,omitempty"`
+	Components  []ComponentRef `json:"components,omitempty"`
+	Parent      *IssueRef      `json:"parent,omitempty"`
+
+	// Extra holds any other fields by ID (e.g. customfield_10010). They
+	// are merged into the marshaled object and win over the typed fields.
+	Extra map[string]interface{} `json:"-"`
+}
+
+func (f CreateIssueFields) MarshalJSON() ([]byte, error) {
+	type plain CreateIssueFields
+	raw, err := json.Marshal(plain(f))
+	if err != nil || len(f.Extra) == 0 {
+		return raw, err
+	}
+
+	var merged map[string]interface{}
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range f.Extra {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
 }
 
 type ProjectRef struct {
@@ -175,6 +379,14 @@ type PriorityRef struct {
 	Name string `json:"name"`
 }
 
+type ComponentRef struct {
+	Name string `json:"name"`
+}
+
+type IssueRef struct {
+	Key string `json:"key"`
+}
+
 type AssigneeRef struct {
 	AccountID string `json:"accountId,omitempty"`
 	Name      string `json:"name,omitempty"`
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// worklogTimeLayout is the timestamp format Jira accepts for a worklog's
+// started field on both API versions.
+const worklogTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+const worklogPageSize = 100
+
+type worklogPage struct {
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Worklogs   []Worklog `json:"worklogs"`
+}
+
+// Estimate adjustment modes, matching Jira's adjustEstimate parameter.
+const (
+	EstimateAuto   = "auto"   // reduce (or restore) the remaining estimate by the time logged
+	EstimateLeave  = "leave"  // leave the remaining estimate unchanged
+	EstimateNew    = "new"    // set the remaining estimate to Amount
+	EstimateManual = "manual" // reduce (or, when deleting, increase) it by Amount
+)
+
+// EstimateAdjustment says what a worklog change does to the issue's
+// remaining estimate. The zero value lets Jira adjust it automatically.
+type EstimateAdjustment struct {
+	Mode   string
+	Amount string // a Jira duration, for EstimateNew and EstimateManual
+}
+
+// query returns the adjustEstimate parameters; manualParam is the name
+// Jira uses for the manual amount (reduceBy when adding, increaseBy when
+// deleting).
+func (a EstimateAdjustment) query(manualParam string) (url.Values, error) {
+	params := url.Values{}
+	switch a.Mode {
+	case "", EstimateAuto:
+		return params, nil
+	case EstimateLeave:
+		params.Set("adjustEstimate", EstimateLeave)
+		return params, nil
+	case EstimateNew, EstimateManual:
+		amount, err := NormalizeDuration(a.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("remaining estimate: %w", err)
+		}
+		params.Set("adjustEstimate", a.Mode)
+		if a.Mode == EstimateNew {
+			params.Set("newEstimate", amount)
+		} else {
+			params.Set(manualParam, amount)
+		}
+		return params, nil
+	}
+	return nil, fmt.Errorf("unknown estimate adjustment %q", a.Mode)
+}
+
+// WorklogOptions describes time to log against an issue. TimeSpent is a
+// Jira duration such as "1h 30m"; a zero Started means now.
+type WorklogOptions struct {
+	TimeSpent string
+	Started   time.Time
+	Comment   string
+	Estimate  EstimateAdjustment
+}
+
+func (c *Client) worklogBody(opts WorklogOptions, started time.Time) ([]byte, error) {
+	body := map[string]interface{}{}
+	if opts.TimeSpent != "" {
+		spent, err := NormalizeDuration(opts.TimeSpent)
+		if err != nil {
+			return nil, err
+		}
+		body["timeSpent"] = spent
+	}
+	if !started.IsZero() {
+		body["started"] = started.Format(worklogTimeLayout)
+	}
+	if opts.Comment != "" {
+		body["comment"] = c.RichText(opts.Comment)
+	}
+
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling worklog: %w", err)
+	}
+	return requestBody, nil
+}
+
+func worklogEndpoint(apiVersion, issueKey, worklogID string, params url.Values) string {
+	endpoint := fmt.Sprintf("/rest/api/%s/issue/%s/worklog", apiVersion, issueKey)
+	if worklogID != "" {
+		endpoint += "/" + url.PathEscape(worklogID)
+	}
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	return endpoint
+}
+
+func (c *Client) GetWorklogs(issueKey string) ([]Worklog, error) {
+	return c.GetWorklogsContext(context.Background(), issueKey)
+}
+
+// GetWorklogsContext returns every worklog on the issue, oldest first.
+func (c *Client) GetWorklogsContext(ctx context.Context, issueKey string) ([]Worklog, error) {
+	var worklogs []Worklog
+	for {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(len(worklogs)))
+		params.Set("maxResults", strconv.Itoa(worklogPageSize))
+
+		resp, err := c.doRequest(ctx, "GET", worklogEndpoint(c.getAPIVersion(), issueKey, "", params), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page worklogPage
+		err = decodeJSON(resp, &page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		worklogs = append(worklogs, page.Worklogs...)
+		// Server/DC returns every worklog in one page regardless of maxResults.
+		if len(page.Worklogs) == 0 || len(worklogs) >= page.Total {
+			return worklogs, nil
+		}
+	}
+}
+
+func (c *Client) AddWorklog(issueKey string, opts WorklogOptions) (*Worklog, error) {
+	return c.AddWorklogContext(context.Background(), issueKey, opts)
+}
+
+func (c *Client) AddWorklogContext(ctx context.Context, issueKey string, opts WorklogOptions) (*Worklog, error) {
+	if opts.TimeSpent == "" {
+		return nil, errors.New("no time spent given")
+	}
+	started := opts.Started
+	if started.IsZero() {
+		started = time.Now()
+	}
+	requestBody, err := c.worklogBody(opts, started)
+	if err != nil {
+		return nil, err
+	}
+	params, err := opts.Estimate.query("reduceBy")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, "POST", worklogEndpoint(c.getAPIVersion(), issueKey, "", params), bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var worklog Worklog
+	return &worklog, decodeJSON(resp, &worklog)
+}
+
+func (c *Client) UpdateWorklog(issueKey, worklogID string, opts WorklogOptions) (*Worklog, error) {
+	return c.UpdateWorklogContext(context.Background(), issueKey, worklogID, opts)
+}
+
+// UpdateWorklogContext changes the fields set in opts and leaves the rest.
+// Jira doesn't support EstimateManual when updating a worklog.
+func (c *Client) UpdateWorklogContext(ctx context.Context, issueKey, worklogID string, opts WorklogOptions) (*Worklog, error) {
+	if opts.Estimate.Mode == EstimateManual {
+		return nil, errors.New("the remaining estimate can't be reduced manually when editing a worklog")
+	}
+	requestBody, err := c.worklogBody(opts, opts.Started)
+	if err != nil {
+		return nil, err
+	}
+	if string(requestBody) == "{}" {
+		return nil, errors.New("nothing to change")
+	}
+	params, err := opts.Estimate.query("")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", worklogEndpoint(c.getAPIVersion(), issueKey, worklogID, params), bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var worklog Worklog
+	return &worklog, decodeJSON(resp, &worklog)
+}
+
+func (c *Client) DeleteWorklog(issueKey, worklogID string, estimate EstimateAdjustment) error {
+	return c.DeleteWorklogContext(context.Background(), issueKey, worklogID, estimate)
+}
+
+func (c *Client) DeleteWorklogContext(ctx context.Context, issueKey, worklogID string, estimate EstimateAdjustment) error {
+	params, err := estimate.query("increaseBy")
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequest(ctx, "DELETE", worklogEndpoint(c.getAPIVersion(), issueKey, worklogID, params), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+var (
+	durationPattern = regexp.MustCompile(`^(?:\s*\d+(?:\.\d+)?\s*[wdhm])+\s*$`)
+	durationPart    = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([wdhm])`)
+)
+
+// NormalizeDuration checks s is a Jira duration such as "1h30m", "2d" or
+// "1.5h" and returns it in the spaced form Jira expects ("1h 30m"). The
+// length of a day and week is configured per instance, so converting to
+// seconds is left to Jira.
+func NormalizeDuration(s string) (string, error) {
+	lower := strings.ToLower(s)
+	if !durationPattern.MatchString(lower) {
+		return "", fmt.Errorf("invalid duration %q: use Jira's format, e.g. 1h30m, 45m or 2d", s)
+	}
+
+	var parts []string
+	for _, m := range durationPart.FindAllStringSubmatch(lower, -1) {
+		if n, _ := strconv.ParseFloat(m[1], 64); n == 0 {
+			continue
+		}
+		parts = append(parts, m[1]+m[2])
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("invalid duration %q: it must be more than zero", s)
+	}
+	return strings.Join(parts, " "), nil
+}
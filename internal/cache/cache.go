@@ -0,0 +1,227 @@
+// Package cache keeps Jira responses on disk so repeated reads are instant
+// and work offline. Each Jira site has its own directory under the user
+// cache directory ($XDG_CACHE_HOME/jira-cli on Linux), holding one JSON
+// file per entry.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Mode decides when stored entries are used instead of asking Jira.
+type Mode int
+
+const (
+	// ModeNormal uses entries younger than their TTL and fetches the rest.
+	ModeNormal Mode = iota
+	// ModeRefresh always fetches, storing what Jira returns (--no-cache).
+	ModeRefresh
+	// ModeOffline uses entries of any age and never fetches (--offline).
+	ModeOffline
+)
+
+// Kinds of entries, each kept in its own subdirectory.
+const (
+	KindIssue    = "issues"
+	KindComments = "comments"
+	KindSearch   = "searches"
+	KindMeta     = "meta"
+)
+
+// Kinds lists every kind of entry.
+var Kinds = []string{KindIssue, KindComments, KindSearch, KindMeta}
+
+// DefaultTTL is how long issues, comments and searches stay fresh.
+const DefaultTTL = 5 * time.Minute
+
+// MetaTTL is how long metadata such as fields and priorities stays fresh;
+// it rarely changes.
+const MetaTTL = 24 * time.Hour
+
+type Cache struct {
+	Dir  string
+	Mode Mode
+	TTL  time.Duration
+	// User is who the cached searches were run as, e.g. the account's
+	// email; searches of other users aren't answered from the cache.
+	User string
+}
+
+// entry is the file format: the stored value and when it was stored.
+type entry struct {
+	Stored time.Time       `json:"stored"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Root returns the directory holding every site's cache.
+func Root() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating cache directory: %w", err)
+	}
+	return filepath.Join(base, "jira-cli"), nil
+}
+
+// Open returns the cache for the Jira site at siteURL. Nothing is created
+// on disk until an entry is stored.
+func Open(siteURL string, mode Mode, ttl time.Duration) (*Cache, error) {
+	root, err := Root()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: filepath.Join(root, SiteName(siteURL)), Mode: mode, TTL: ttl}, nil
+}
+
+// SiteName turns a site URL into a directory name, e.g.
+// "https://example.atlassian.net/" into "example.atlassian.net".
+func SiteName(siteURL string) string {
+	name := siteURL
+	if u, err := url.Parse(siteURL); err == nil && u.Host != "" {
+		name = u.Host + strings.TrimRight(u.Path, "/")
+	}
+	name = strings.ToLower(name)
+	return strings.NewReplacer("/", "_", ":", "_", "\\", "_").Replace(name)
+}
+
+func (c *Cache) path(kind, key string) string {
+	return filepath.Join(c.Dir, kind, url.PathEscape(key)+".json")
+}
+
+// Get decodes the stored entry into v whatever its age, returning when it
+// was stored. A missing or unreadable entry reports false.
+func (c *Cache) Get(kind, key string, v interface{}) (time.Time, bool) {
+	data, err := os.ReadFile(c.path(kind, key))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return time.Time{}, false
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return time.Time{}, false
+	}
+	return e.Stored, true
+}
+
+// Lookup decodes the entry into v if the mode allows using it instead of
+// asking Jira.
+func (c *Cache) Lookup(kind, key string, v interface{}) bool {
+	if c.Mode == ModeRefresh {
+		return false
+	}
+	stored, ok := c.Get(kind, key, v)
+	if !ok {
+		return false
+	}
+	return c.Mode == ModeOffline || time.Since(stored) < c.ttl(kind)
+}
+
+func (c *Cache) ttl(kind string) time.Duration {
+	if kind == KindMeta {
+		return MetaTTL
+	}
+	return c.TTL
+}
+
+// Put stores v, replacing any earlier entry.
+func (c *Cache) Put(kind, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding cache entry: %w", err)
+	}
+	data, err = json.Marshal(entry{Stored: time.Now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("encoding cache entry: %w", err)
+	}
+
+	path := c.path(kind, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	// Write to a temporary file first so concurrent readers never see a
+	// partial entry.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	return nil
+}
+
+// Delete drops one entry; a missing entry is not an error.
+func (c *Cache) Delete(kind, key string) error {
+	err := os.Remove(c.path(kind, key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// DeleteKind drops every entry of a kind.
+func (c *Cache) DeleteKind(kind string) error {
+	return os.RemoveAll(filepath.Join(c.Dir, kind))
+}
+
+// Clear drops the whole site's cache.
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
+
+// KindStats summarizes the entries of one kind.
+type KindStats struct {
+	Kind    string
+	Entries int
+	Bytes   int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// Stats summarizes the cache by kind, going by the files' modification
+// times, which match when they were stored.
+func (c *Cache) Stats() ([]KindStats, error) {
+	stats := make([]KindStats, len(Kinds))
+	for i, kind := range Kinds {
+		stats[i].Kind = kind
+		files, err := os.ReadDir(filepath.Join(c.Dir, kind))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading cache: %w", err)
+		}
+		for _, file := range files {
+			info, err := file.Info()
+			if err != nil || !strings.HasSuffix(file.Name(), ".json") {
+				continue
+			}
+			s := &stats[i]
+			s.Entries++
+			s.Bytes += info.Size()
+			if s.Oldest.IsZero() || info.ModTime().Before(s.Oldest) {
+				s.Oldest = info.ModTime()
+			}
+			if info.ModTime().After(s.Newest) {
+				s.Newest = info.ModTime()
+			}
+		}
+	}
+	return stats, nil
+}
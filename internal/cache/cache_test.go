@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLookupFollowsMode(t *testing.T) {
+	tests := []struct {
+		name string
+		mode Mode
+		ttl  time.Duration
+		want bool
+	}{
+		{"fresh", ModeNormal, time.Hour, true},
+		{"stale", ModeNormal, time.Nanosecond, false},
+		{"refresh", ModeRefresh, time.Hour, false},
+		{"offline uses stale entries", ModeOffline, time.Nanosecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cache{Dir: t.TempDir(), Mode: tt.mode, TTL: tt.ttl}
+			if err := c.Put(KindIssue, "PROJ-1", map[string]string{"key": "PROJ-1"}); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(time.Millisecond)
+
+			var got map[string]string
+			if ok := c.Lookup(KindIssue, "PROJ-1", &got); ok != tt.want {
+				t.Fatalf("Lookup() = %v, want %v", ok, tt.want)
+			}
+			if tt.want && got["key"] != "PROJ-1" {
+				t.Errorf("Lookup() decoded %v", got)
+			}
+		})
+	}
+}
+
+func TestMetaOutlivesTTL(t *testing.T) {
+	c := &Cache{Dir: t.TempDir(), TTL: time.Nanosecond}
+	if err := c.Put(KindMeta, "fields", []string{"summary"}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	var fields []string
+	if !c.Lookup(KindMeta, "fields", &fields) {
+		t.Error("metadata went stale after the issue TTL")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	c := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+	for _, key := range []string{"PROJ-1", "PROJ-2"} {
+		if err := c.Put(KindIssue, key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Put(KindSearch, "q", "result"); err != nil {
+		t.Fatal(err)
+	}
+
+	var v string
+	if err := c.Delete(KindIssue, "PROJ-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(KindIssue, "PROJ-404"); err != nil {
+		t.Errorf("Delete() of a missing entry: %v", err)
+	}
+	if c.Lookup(KindIssue, "PROJ-1", &v) || !c.Lookup(KindIssue, "PROJ-2", &v) {
+		t.Error("Delete() dropped the wrong entries")
+	}
+
+	if err := c.DeleteKind(KindIssue); err != nil {
+		t.Fatal(err)
+	}
+	if c.Lookup(KindIssue, "PROJ-2", &v) || !c.Lookup(KindSearch, "q", &v) {
+		t.Error("DeleteKind() dropped the wrong entries")
+	}
+
+	stats, err := c.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range stats {
+		want := 0
+		if s.Kind == KindSearch {
+			want = 1
+		}
+		if s.Entries != want {
+			t.Errorf("Stats() has %d %s, want %d", s.Entries, s.Kind, want)
+		}
+	}
+}
+
+func TestKeysAreEscaped(t *testing.T) {
+	c := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+	key := "project = PROJ/../x ORDER BY rank"
+	if err := c.Put(KindSearch, key, 1); err != nil {
+		t.Fatal(err)
+	}
+	var v int
+	if !c.Lookup(KindSearch, key, &v) || v != 1 {
+		t.Error("entry with a path-like key was not found")
+	}
+}
+
+func TestSiteName(t *testing.T) {
+	tests := map[string]string{
+		"https://Example.atlassian.net/":      "example.atlassian.net",
+		"https://jira.example.com:8443/jira/": "jira.example.com_8443_jira",
+		"not a url":                           "not a url",
+	}
+	for siteURL, want := range tests {
+		if got := SiteName(siteURL); got != want {
+			t.Errorf("SiteName(%q) = %q, want %q", siteURL, got, want)
+		}
+	}
+}
@@ -3,9 +3,14 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/cache"
+	"github.com/danielyan21/JiraCLI/internal/git"
+	"github.com/danielyan21/JiraCLI/internal/timer"
+	"github.com/danielyan21/JiraCLI/internal/ui"
 	"github.com/spf13/viper"
 )
 
@@ -15,9 +20,28 @@ type Config struct {
 	APIToken       string `mapstructure:"api_token"`
 	AuthType       string `mapstructure:"auth_type"` // "basic", "pat", "bearer"
 	DefaultProject string `mapstructure:"default_project"`
+	DefaultBoard   int    `mapstructure:"default_board"`    // agile board for sprint commands when the project has several
+	MaxRetries     int    `mapstructure:"max_retries"`      // retries for rate-limited/transient failures
+	RetryPOST      bool   `mapstructure:"retry_post"`       // also retry non-idempotent POSTs
+	APITokenCmd    string `mapstructure:"api_token_cmd"`    // shell command printing the token, e.g. "pass show jira"
+	TokenStore     string `mapstructure:"token_store"`      // "keyring" or "file"; empty means api_token above
+	TimerRounding  string `mapstructure:"timer_rounding"`   // "up", "nearest" or "down"; see TimerRule
+	TimerRoundTo   string `mapstructure:"timer_round_to"`   // rounding step for timed work, e.g. "15m"
+	CacheTTL       string `mapstructure:"cache_ttl"`        // how long cached issues stay fresh, e.g. "10m"; "0" always fetches
+	BranchTemplate string `mapstructure:"branch_template"`  // name of branches made by `jira branch`; see git.BranchName
+	BranchKeyRegex string `mapstructure:"branch_key_regex"` // finds the ticket key in branch names; see git.TicketKeys
+
+	Profile string      `mapstructure:"-"` // name of the profile these settings came from
+	Secrets SecretStore `mapstructure:"-"` // overrides TokenStore, e.g. with NewMemorySecretStore
 }
 
-func InitializeConfig() error {
+// InitializeConfig prompts for connection settings and saves them as the
+// named profile in the config file.
+func InitializeConfig(profile string) error {
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+
 	var jiraURL string
 	urlPrompt := &survey.Input{
 		Message: "Jira URL:",
@@ -46,8 +70,8 @@ func InitializeConfig() error {
 	switch authMethod {
 	case "Personal Access Token (Jira Server/DC)":
 		authType = "pat"
-		fmt.Printf("\nTo create a PAT, go to: %s/secure/ViewProfile.jspa\n", jiraURL)
-		fmt.Println("Then click 'Personal Access Tokens' in the sidebar\n")
+		ui.Progress("\nTo create a PAT, go to: %s/secure/ViewProfile.jspa\n", jiraURL)
+		ui.Progress("Then click 'Personal Access Tokens' in the sidebar\n\n")
 
 		patPrompt := &survey.Password{
 			Message: "Personal Access Token:",
@@ -99,36 +123,95 @@ func InitializeConfig() error {
 	}
 	survey.AskOne(projectPrompt, &defaultProject)
 
-	viper.Set("jira_url", jiraURL)
-	viper.Set("auth_type", authType)
-	viper.Set("email", email)
-	viper.Set("api_token", apiToken)
-	viper.Set("default_project", defaultProject)
-
-	home, err := os.UserHomeDir()
+	tokenStore, err := askTokenStore()
 	if err != nil {
-		return fmt.Errorf("getting home directory: %w", err)
+		return err
 	}
 
-	configPath := home + "/.jira-cli.yaml"
+	// A nil value removes the key, so switching storage never leaves a stale
+	// plaintext token or token_store behind.
+	values := map[string]interface{}{
+		"jira_url":        jiraURL,
+		"auth_type":       authType,
+		"email":           email,
+		"default_project": defaultProject,
+		"token_store":     tokenStore,
+		"api_token":       nil,
+	}
 
-	if err := viper.WriteConfigAs(configPath); err != nil {
-		return fmt.Errorf("writing config file: %w", err)
+	if tokenStore == "" {
+		values["api_token"] = apiToken
+		values["token_store"] = nil
+	} else {
+		store, err := NewSecretStore(tokenStore)
+		if err != nil {
+			return err
+		}
+		if err := store.Set(profile, apiToken); err != nil {
+			return fmt.Errorf("storing API token: %w", err)
+		}
 	}
 
-	if err := os.Chmod(configPath, 0600); err != nil {
-		return fmt.Errorf("setting config file permissions: %w", err)
+	configPath, err := saveProfile(profile, values)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("\nConfiguration saved to: %s\n", configPath)
+	ui.Progress("\nProfile '%s' saved to: %s\n", profile, configPath)
 	return nil
 }
 
+// askTokenStore asks where to keep the API token, defaulting to the OS
+// keyring when one is available. It returns a token_store value.
+func askTokenStore() (string, error) {
+	const (
+		keyringOption   = "OS keyring"
+		fileOption      = "Encrypted file"
+		plaintextOption = "Config file (plaintext)"
+	)
+
+	options := []string{fileOption, plaintextOption}
+	if KeyringAvailable() {
+		options = append([]string{keyringOption}, options...)
+	}
+
+	var choice string
+	storePrompt := &survey.Select{
+		Message: "Store API token in:",
+		Options: options,
+		Default: options[0],
+		Help:    "The encrypted file is for machines without a keyring; it needs " + passphraseEnv + " set to a passphrase",
+	}
+	if err := survey.AskOne(storePrompt, &choice); err != nil {
+		return "", err
+	}
+
+	switch choice {
+	case keyringOption:
+		return TokenStoreKeyring, nil
+	case fileOption:
+		if os.Getenv(passphraseEnv) == "" {
+			return "", fmt.Errorf("%w, e.g. in your shell profile; then run 'jira init' again", errPassphraseRequired)
+		}
+		return TokenStoreFile, nil
+	}
+	return "", nil
+}
+
+// LoadConfig returns the settings of the active profile (see ActiveProfile).
 func LoadConfig() (*Config, error) {
+	name := ActiveProfile()
+	v, err := loadProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	v.SetDefault("max_retries", api.DefaultRetryPolicy().MaxRetries)
+
 	var cfg Config
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	cfg.Profile = name
 	return &cfg, nil
 }
 
@@ -143,8 +226,15 @@ func ValidateConfig(cfg *Config) error {
 		}
 	}
 
-	if cfg.APIToken == "" {
-		return fmt.Errorf("api_token is required")
+	if cfg.APIToken == "" && cfg.APITokenCmd == "" && cfg.TokenStore == "" && cfg.Secrets == nil {
+		return fmt.Errorf("api_token, api_token_cmd or token_store is required")
+	}
+
+	if _, err := cfg.CacheDuration(); err != nil {
+		return err
+	}
+	if _, err := git.CompileKeyPattern(cfg.BranchKeyRegex); err != nil {
+		return err
 	}
 	return nil
 }
@@ -166,10 +256,60 @@ func LoadAndValidate() *Config {
 	return cfg
 }
 
+// TimerRule returns how elapsed timer time is rounded before it's logged.
+func (cfg *Config) TimerRule() (timer.Rule, error) {
+	return timer.ParseRule(cfg.TimerRounding, cfg.TimerRoundTo)
+}
+
 func (cfg *Config) NewAPIClient() *api.Client {
 	authType := cfg.AuthType
 	if authType == "" {
 		authType = "basic"
 	}
-	return api.NewClientWithAuthType(cfg.JiraURL, cfg.Email, cfg.APIToken, authType)
+	client := api.NewClientWithAuthType(cfg.JiraURL, cfg.Email, cfg.APIToken, authType)
+	client.TokenSource = cfg.resolveAPIToken
+	client.Retry.MaxRetries = cfg.MaxRetries
+	client.Retry.RetryPOST = cfg.RetryPOST
+	client.Cache = cfg.OpenCache()
+	return client
+}
+
+// CacheDuration returns how long cached issues, comments and searches are
+// used before Jira is asked again.
+func (cfg *Config) CacheDuration() (time.Duration, error) {
+	if cfg.CacheTTL == "" {
+		return cache.DefaultTTL, nil
+	}
+	ttl, err := time.ParseDuration(cfg.CacheTTL)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid cache_ttl %q: use a duration such as 10m", cfg.CacheTTL)
+	}
+	return ttl, nil
+}
+
+// OpenCache returns the cache of the configured site, in the mode the
+// --offline and --no-cache flags select. It's nil if there's no cache
+// directory, in which case everything is fetched.
+func (cfg *Config) OpenCache() *cache.Cache {
+	mode := cache.ModeNormal
+	switch {
+	case viper.GetBool("offline"):
+		mode = cache.ModeOffline
+	case viper.GetBool("no_cache"):
+		mode = cache.ModeRefresh
+	}
+
+	ttl, err := cfg.CacheDuration()
+	if err != nil {
+		ttl = cache.DefaultTTL
+	}
+	c, err := cache.Open(cfg.JiraURL, mode, ttl)
+	if err != nil {
+		return nil
+	}
+	c.User = cfg.Email
+	if c.User == "" {
+		c.User = "profile " + cfg.Profile
+	}
+	return c
 }
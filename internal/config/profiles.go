@@ -0,0 +1,260 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultProfileName is used when neither --profile, JIRA_PROFILE nor the
+// config file's default_profile pick one.
+const DefaultProfileName = "default"
+
+// profileKeys are the settings that live inside a profile. Before profiles
+// existed they were stored at the top level of the config file.
+var profileKeys = []string{
+	"jira_url", "email", "api_token", "auth_type", "default_project",
+	"max_retries", "retry_post",
+}
+
+// Profile names become viper key segments, so dots and spaces are not allowed.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+type Profile struct {
+	Name    string
+	Config  Config
+	Default bool
+}
+
+// ActiveProfile resolves the profile to use: the --profile flag, then the
+// JIRA_PROFILE environment variable (both via viper's "profile" key), then
+// default_profile from the config file.
+func ActiveProfile() string {
+	if name := viper.GetString("profile"); name != "" {
+		return name
+	}
+	if name := viper.GetString("default_profile"); name != "" {
+		return name
+	}
+	return DefaultProfileName
+}
+
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ListProfiles returns every profile in the config file, sorted by name.
+func ListProfiles() ([]Profile, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	migrateLegacySettings(settings)
+
+	defaultName, _ := settings["default_profile"].(string)
+	if defaultName == "" {
+		defaultName = DefaultProfileName
+	}
+
+	profiles := profilesOf(settings)
+	var result []Profile
+	for name, raw := range profiles {
+		v := viper.New()
+		if values, ok := raw.(map[string]interface{}); ok {
+			v.MergeConfigMap(values)
+		}
+		var cfg Config
+		if err := v.Unmarshal(&cfg); err != nil {
+			return nil, fmt.Errorf("reading profile %q: %w", name, err)
+		}
+		result = append(result, Profile{Name: name, Config: cfg, Default: name == defaultName})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// UseProfile makes name the default profile for future commands.
+func UseProfile(name string) error {
+	return updateConfigFile(func(settings map[string]interface{}) error {
+		if _, ok := profilesOf(settings)[name]; !ok {
+			return fmt.Errorf("profile %q not found", name)
+		}
+		settings["default_profile"] = name
+		return nil
+	})
+}
+
+// RemoveProfile deletes a profile along with any token it kept in a secret
+// store. If it was the default, default_profile is cleared so the next
+// command falls back to DefaultProfileName.
+func RemoveProfile(name string) error {
+	return updateConfigFile(func(settings map[string]interface{}) error {
+		profiles := profilesOf(settings)
+		profile, ok := profiles[name]
+		if !ok {
+			return fmt.Errorf("profile %q not found", name)
+		}
+		if values, ok := profile.(map[string]interface{}); ok {
+			if kind, _ := values["token_store"].(string); kind != "" {
+				store, err := NewSecretStore(kind)
+				if err == nil {
+					err = store.Delete(name)
+				}
+				if err != nil {
+					return fmt.Errorf("removing stored API token: %w", err)
+				}
+			}
+		}
+		delete(profiles, name)
+		if settings["default_profile"] == name {
+			delete(settings, "default_profile")
+		}
+		return nil
+	})
+}
+
+// saveProfile merges values into the named profile, creating it if needed;
+// nil values delete their key. The first profile ever saved becomes the default.
+func saveProfile(name string, values map[string]interface{}) (string, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return "", err
+	}
+	err = updateConfigFile(func(settings map[string]interface{}) error {
+		profiles := profilesOf(settings)
+		profile, _ := profiles[name].(map[string]interface{})
+		if profile == nil {
+			profile = map[string]interface{}{}
+		}
+		for key, value := range values {
+			if value == nil {
+				delete(profile, key)
+			} else {
+				profile[key] = value
+			}
+		}
+		profiles[name] = profile
+
+		if _, ok := settings["default_profile"]; !ok || len(profiles) == 1 {
+			settings["default_profile"] = name
+		}
+		return nil
+	})
+	return path, err
+}
+
+// loadProfile reads the named profile from the config viper has already
+// loaded. Files written before profiles existed are treated as holding a
+// single "default" profile.
+func loadProfile(name string) (*viper.Viper, error) {
+	if !viper.IsSet("profiles") {
+		if name != DefaultProfileName && name != viper.GetString("default_profile") {
+			return nil, fmt.Errorf("profile %q not found", name)
+		}
+		return viper.GetViper(), nil
+	}
+
+	sub := viper.Sub("profiles." + name)
+	if sub == nil {
+		return nil, fmt.Errorf("profile %q not found", name)
+	}
+	return sub, nil
+}
+
+func profilesOf(settings map[string]interface{}) map[string]interface{} {
+	profiles, _ := settings["profiles"].(map[string]interface{})
+	if profiles == nil {
+		profiles = map[string]interface{}{}
+		settings["profiles"] = profiles
+	}
+	return profiles
+}
+
+// migrateLegacySettings moves top-level connection settings from a
+// pre-profiles config file into profiles.default.
+func migrateLegacySettings(settings map[string]interface{}) {
+	if _, ok := settings["profiles"]; ok {
+		return
+	}
+	legacy := map[string]interface{}{}
+	for _, key := range profileKeys {
+		if value, ok := settings[key]; ok {
+			legacy[key] = value
+			delete(settings, key)
+		}
+	}
+	if len(legacy) == 0 {
+		return
+	}
+	profilesOf(settings)[DefaultProfileName] = legacy
+	if _, ok := settings["default_profile"]; !ok {
+		settings["default_profile"] = DefaultProfileName
+	}
+}
+
+func configFilePath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("getting home directory: %w", err)
+	}
+	return filepath.Join(home, ".jira-cli.yaml"), nil
+}
+
+// readConfigFile loads the raw config file into a map. A fresh viper instance
+// is used so bound flags and environment variables don't leak into it.
+func readConfigFile(path string) (map[string]interface{}, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]interface{}{}, nil
+		}
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return v.AllSettings(), nil
+}
+
+func updateConfigFile(update func(settings map[string]interface{}) error) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	settings, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	migrateLegacySettings(settings)
+
+	if err := update(settings); err != nil {
+		return err
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	for key, value := range settings {
+		v.Set(key, value)
+	}
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("setting config file permissions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// secretService namespaces our entries in the OS keyring and the encrypted file.
+const secretService = "jira-cli"
+
+// Token store kinds, selected per profile with the token_store setting. An
+// empty token_store means api_token is read from the config file as before.
+const (
+	TokenStoreKeyring = "keyring"
+	TokenStoreFile    = "file"
+)
+
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore keeps API tokens out of the plaintext config file. Secrets are
+// addressed by account, which is the profile name.
+type SecretStore interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// NewSecretStore opens the store for a token_store setting.
+func NewSecretStore(kind string) (SecretStore, error) {
+	switch kind {
+	case TokenStoreKeyring:
+		return newKeyringStore()
+	case TokenStoreFile:
+		return newEncryptedFileStore()
+	}
+	return nil, fmt.Errorf("unknown token_store %q (expected %q or %q)", kind, TokenStoreKeyring, TokenStoreFile)
+}
+
+// KeyringAvailable reports whether an OS keyring is installed and
+// answering, so callers can fall back to the encrypted file on headless
+// machines.
+func KeyringAvailable() bool {
+	store, err := newKeyringStore()
+	return err == nil && store.probe() == nil
+}
+
+// MemorySecretStore is an in-memory SecretStore for tests and embedding.
+type MemorySecretStore struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+func NewMemorySecretStore() *MemorySecretStore {
+	return &MemorySecretStore{secrets: map[string]string{}}
+}
+
+func (m *MemorySecretStore) Get(account string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, ok := m.secrets[account]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func (m *MemorySecretStore) Set(account, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secrets[account] = secret
+	return nil
+}
+
+func (m *MemorySecretStore) Delete(account string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.secrets, account)
+	return nil
+}
+
+// resolveAPIToken finds the token for cfg: an inline api_token wins, then
+// api_token_cmd, then the configured secret store.
+func (cfg *Config) resolveAPIToken(ctx context.Context) (string, error) {
+	if cfg.APIToken != "" {
+		return cfg.APIToken, nil
+	}
+
+	if cfg.APITokenCmd != "" {
+		return runTokenCommand(ctx, cfg.APITokenCmd)
+	}
+
+	store := cfg.Secrets
+	if store == nil {
+		if cfg.TokenStore == "" {
+			return "", fmt.Errorf("no api_token configured")
+		}
+		var err error
+		store, err = NewSecretStore(cfg.TokenStore)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	token, err := store.Get(cfg.Profile)
+	if errors.Is(err, ErrSecretNotFound) {
+		return "", fmt.Errorf("no API token stored for profile %q; run 'jira init --profile %s'", cfg.Profile, cfg.Profile)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading API token from %s: %w", cfg.TokenStore, err)
+	}
+	return token, nil
+}
+
+// runTokenCommand runs api_token_cmd through the shell (e.g. "pass show jira")
+// and uses the first line of its output as the token.
+func runTokenCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("running api_token_cmd: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("running api_token_cmd: %w", err)
+	}
+
+	token, _, _ := strings.Cut(string(out), "\n")
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("api_token_cmd printed no token")
+	}
+	return token, nil
+}
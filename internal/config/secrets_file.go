@@ -0,0 +1,178 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/danielyan21/JiraCLI/internal/fileutil"
+)
+
+const (
+	secretsFileName = "secrets.enc"
+
+	// passphraseEnv holds the passphrase the file key is derived from. It's
+	// required: a key stored next to the file would protect nothing.
+	passphraseEnv = "JIRA_SECRETS_PASSPHRASE"
+
+	pbkdf2Iterations = 600_000
+	saltSize         = 16
+	keySize          = 32
+)
+
+// encryptedFileStore is the fallback for machines without a keyring (e.g.
+// headless Linux). Secrets are kept in one AES-256-GCM encrypted JSON file
+// under the user config directory, with a key derived from
+// JIRA_SECRETS_PASSPHRASE.
+type encryptedFileStore struct {
+	dir string
+}
+
+// sealedFile is the on-disk layout of secrets.enc.
+type sealedFile struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func newEncryptedFileStore() (*encryptedFileStore, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("locating config directory: %w", err)
+	}
+	return &encryptedFileStore{dir: filepath.Join(base, "jira-cli")}, nil
+}
+
+func (f *encryptedFileStore) Get(account string) (string, error) {
+	secrets, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[account]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func (f *encryptedFileStore) Set(account, secret string) error {
+	secrets, err := f.load()
+	if err != nil {
+		return err
+	}
+	secrets[account] = secret
+	return f.save(secrets)
+}
+
+func (f *encryptedFileStore) Delete(account string) error {
+	secrets, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[account]; !ok {
+		return nil
+	}
+	delete(secrets, account)
+	return f.save(secrets)
+}
+
+func (f *encryptedFileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(f.dir, secretsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading secrets file: %w", err)
+	}
+
+	var sealed sealedFile
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, fmt.Errorf("parsing secrets file: %w", err)
+	}
+
+	gcm, err := f.cipher(sealed.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting secrets file (wrong %s?): %w", passphraseEnv, err)
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("parsing decrypted secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+func (f *encryptedFileStore) save(secrets map[string]string) error {
+	if err := os.MkdirAll(f.dir, 0700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	if os.Getenv(passphraseEnv) == "" {
+		return errPassphraseRequired
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	gcm, err := f.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(sealedFile{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteAtomic(filepath.Join(f.dir, secretsFileName), data, 0600)
+}
+
+// errPassphraseRequired is returned when writing secrets without a
+// passphrase to derive the key from.
+var errPassphraseRequired = fmt.Errorf("the encrypted file token store needs %s set to a passphrase", passphraseEnv)
+
+// cipher builds the AES-GCM cipher with a key derived from
+// JIRA_SECRETS_PASSPHRASE and salt.
+func (f *encryptedFileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	if len(salt) == 0 {
+		return nil, errors.New("secrets file has no salt; remove it and store the token again")
+	}
+	passphrase := os.Getenv(passphraseEnv)
+	if passphrase == "" {
+		return nil, fmt.Errorf("secrets file is passphrase-protected; set %s", passphraseEnv)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// errSecNotFound is security's exit status when no keychain item matches
+// (errSecItemNotFound).
+const errSecNotFound = 44
+
+// probeAccount is looked up to check that the keyring answers; it's never
+// stored.
+const probeAccount = "jira-cli-probe"
+
+// keyringStore talks to the OS keyring through its command-line tool:
+// secret-tool (freedesktop Secret Service, e.g. GNOME Keyring/KWallet) on
+// Linux and BSD, and security (Keychain) on macOS.
+type keyringStore struct {
+	tool string
+}
+
+// keyringError is a failure the keyring tool reported, e.g. a locked
+// keyring or no Secret Service on the session bus.
+type keyringError struct {
+	tool   string
+	code   int
+	stderr string
+}
+
+func (e *keyringError) Error() string {
+	if e.stderr != "" {
+		return fmt.Sprintf("%s: %s", e.tool, e.stderr)
+	}
+	return fmt.Sprintf("%s exited with status %d", e.tool, e.code)
+}
+
+func newKeyringStore() (*keyringStore, error) {
+	tool := "secret-tool"
+	if runtime.GOOS == "darwin" {
+		tool = "security"
+	}
+	path, err := exec.LookPath(tool)
+	if err != nil {
+		return nil, fmt.Errorf("OS keyring unavailable (%s not found); use token_store: file instead", tool)
+	}
+	return &keyringStore{tool: path}, nil
+}
+
+// probe checks that the keyring service answers, not just that its tool
+// is installed: secret-tool is often present without a Secret Service.
+func (k *keyringStore) probe() error {
+	if runtime.GOOS == "darwin" {
+		_, err := k.run("", "default-keychain")
+		return err
+	}
+	_, err := k.Get(probeAccount)
+	if errors.Is(err, ErrSecretNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (k *keyringStore) Get(account string) (string, error) {
+	var args []string
+	if runtime.GOOS == "darwin" {
+		args = []string{"find-generic-password", "-s", secretService, "-a", account, "-w"}
+	} else {
+		args = []string{"lookup", "service", secretService, "account", account}
+	}
+
+	out, err := k.run("", args...)
+	if k.isNotFound(err) {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	secret := strings.TrimRight(out, "\n")
+	if secret == "" {
+		return "", ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func (k *keyringStore) Set(account, secret string) error {
+	var err error
+	if runtime.GOOS == "darwin" {
+		// A trailing -w without a value makes security read the password
+		// from stdin (twice, to confirm it), keeping it off the command line
+		// where ps would show it. -U updates an existing item in place.
+		_, err = k.run(secret+"\n"+secret+"\n", "add-generic-password", "-U", "-s", secretService, "-a", account, "-w")
+	} else {
+		label := fmt.Sprintf("JiraCLI API token (%s)", account)
+		_, err = k.run(secret, "store", "--label", label, "service", secretService, "account", account)
+	}
+	return err
+}
+
+func (k *keyringStore) Delete(account string) error {
+	var err error
+	if runtime.GOOS == "darwin" {
+		_, err = k.run("", "delete-generic-password", "-s", secretService, "-a", account)
+	} else {
+		_, err = k.run("", "clear", "service", secretService, "account", account)
+	}
+	if k.isNotFound(err) {
+		return nil // already gone
+	}
+	return err
+}
+
+// isNotFound reports whether err is the tool saying nothing matched:
+// security's errSecItemNotFound status, or secret-tool failing without a
+// message. Other failures, such as a locked keyring, are real errors.
+func (k *keyringStore) isNotFound(err error) bool {
+	var keyErr *keyringError
+	if !errors.As(err, &keyErr) {
+		return false
+	}
+	if runtime.GOOS == "darwin" {
+		return keyErr.code == errSecNotFound
+	}
+	return keyErr.code == 1 && keyErr.stderr == ""
+}
+
+func (k *keyringStore) run(stdin string, args ...string) (string, error) {
+	cmd := exec.Command(k.tool, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", &keyringError{tool: filepath.Base(k.tool) + " " + args[0], code: exitErr.ExitCode(), stderr: strings.TrimSpace(stderr.String())}
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
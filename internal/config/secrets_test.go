@@ -0,0 +1,162 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedFileStore(t *testing.T) {
+	t.Setenv(passphraseEnv, "correct horse")
+	dir := t.TempDir()
+	store := &encryptedFileStore{dir: dir}
+
+	if _, err := store.Get("work"); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("Get on an empty store = %v, want ErrSecretNotFound", err)
+	}
+	if err := store.Set("work", "token-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("home", "token-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second store reads what the first wrote.
+	reopened := &encryptedFileStore{dir: dir}
+	if got, err := reopened.Get("work"); err != nil || got != "token-1" {
+		t.Errorf("Get(work) = %q, %v, want token-1", got, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, secretsFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("token-1")) {
+		t.Error("the secrets file holds the token in plaintext")
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 1 {
+		t.Errorf("the store wrote %d files, want only %s", len(files), secretsFileName)
+	}
+
+	if err := store.Delete("work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("work"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrSecretNotFound", err)
+	}
+	if got, err := store.Get("home"); err != nil || got != "token-2" {
+		t.Errorf("Get(home) after deleting work = %q, %v, want token-2", got, err)
+	}
+
+	t.Setenv(passphraseEnv, "wrong")
+	if _, err := store.Get("home"); err == nil {
+		t.Error("Get with the wrong passphrase succeeded")
+	}
+}
+
+func TestEncryptedFileStoreNeedsPassphrase(t *testing.T) {
+	t.Setenv(passphraseEnv, "")
+	store := &encryptedFileStore{dir: t.TempDir()}
+	if err := store.Set("work", "token"); !errors.Is(err, errPassphraseRequired) {
+		t.Errorf("Set without a passphrase = %v, want errPassphraseRequired", err)
+	}
+}
+
+func TestEncryptedFileStoreNeedsSalt(t *testing.T) {
+	dir := t.TempDir()
+	key := make([]byte, keySize)
+	rand.Read(key)
+	block, _ := aes.NewCipher(key)
+	gcm, _ := cipher.NewGCM(block)
+	nonce := make([]byte, gcm.NonceSize())
+	rand.Read(nonce)
+	plaintext, _ := json.Marshal(map[string]string{"work": "token"})
+	data, _ := json.Marshal(sealedFile{Nonce: nonce, Ciphertext: gcm.Seal(nil, nonce, plaintext, nil)})
+	if err := os.WriteFile(filepath.Join(dir, secretsFileName), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(passphraseEnv, "correct horse")
+	store := &encryptedFileStore{dir: dir}
+	if _, err := store.Get("work"); err == nil || !strings.Contains(err.Error(), "no salt") {
+		t.Errorf("Get from a file without a salt = %v, want a missing salt error", err)
+	}
+}
+
+func TestResolveAPIToken(t *testing.T) {
+	stored := NewMemorySecretStore()
+	stored.Set("work", "stored-token")
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    string
+		wantErr string
+	}{
+		{"inline token wins", Config{APIToken: "inline", APITokenCmd: "echo cmd", Secrets: stored, Profile: "work"}, "inline", ""},
+		{"token command", Config{APITokenCmd: "printf 'cmd-token\\nsecond line\\n'", Secrets: stored, Profile: "work"}, "cmd-token", ""},
+		{"token command without output", Config{APITokenCmd: "true"}, "", "printed no token"},
+		{"secret store", Config{Secrets: stored, Profile: "work"}, "stored-token", ""},
+		{"nothing stored for the profile", Config{Secrets: stored, Profile: "home"}, "", "jira init --profile home"},
+		{"no token at all", Config{Profile: "work"}, "", "no api_token configured"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" && tt.cfg.APITokenCmd != "" {
+				t.Skip("token commands are sh syntax")
+			}
+			got, err := tt.cfg.resolveAPIToken(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("resolveAPIToken() = %q, %v, want an error containing %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("resolveAPIToken() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyringNotFound(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("fakes secret-tool, the Linux keyring tool")
+	}
+	// The fake secret-tool behaves according to the account looked up.
+	tool := filepath.Join(t.TempDir(), "secret-tool")
+	script := `#!/bin/sh
+case "$5" in
+found) echo "s3cret" ;;
+missing) exit 1 ;;
+locked) echo "secret-tool: Cannot get secret of a locked object" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(tool, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	store := &keyringStore{tool: tool}
+
+	if got, err := store.Get("found"); err != nil || got != "s3cret" {
+		t.Errorf("Get(found) = %q, %v, want s3cret", got, err)
+	}
+	if _, err := store.Get("missing"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Get(missing) = %v, want ErrSecretNotFound", err)
+	}
+	_, err := store.Get("locked")
+	if err == nil || errors.Is(err, ErrSecretNotFound) || !strings.Contains(err.Error(), "locked") {
+		t.Errorf("Get(locked) = %v, want the tool's error", err)
+	}
+	if err := store.probe(); err != nil {
+		t.Errorf("probe() = %v with a working keyring", err)
+	}
+}
@@ -0,0 +1,43 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	for _, content := range []string{"first", "second"} {
+		if err := WriteAtomic(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("file holds %q, want %q", data, content)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 1 {
+		t.Errorf("%d files left in the directory, want 1", len(files))
+	}
+}
+
+func TestWriteAtomicMissingDir(t *testing.T) {
+	if err := WriteAtomic(filepath.Join(t.TempDir(), "missing", "f"), nil, 0600); err == nil {
+		t.Error("WriteAtomic into a missing directory succeeded")
+	}
+}
@@ -0,0 +1,96 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultBranchTemplate names branches like "story/PROJ-123-add-login-page".
+const DefaultBranchTemplate = "{{.Type}}/{{.Key}}-{{slug .Summary}}"
+
+// maxSlugLength keeps slugged summaries from making unwieldy branch names.
+const maxSlugLength = 50
+
+// BranchData is what a branch name template can use.
+type BranchData struct {
+	Key     string // issue key, e.g. "PROJ-123"
+	Summary string // issue summary as written
+	Type    string // issue type as a slug, e.g. "story", "bug"
+	Project string // project key
+}
+
+var branchFuncs = template.FuncMap{
+	"slug":  Slug,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// BranchName renders a branch name template (text/template syntax, with
+// the functions slug, lower and upper). Any whitespace left in the result
+// becomes a hyphen, and path segments left empty by empty values are
+// dropped, so "{{.Type}}/{{.Key}}" without a type is just the key.
+func BranchName(tmpl string, data BranchData) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultBranchTemplate
+	}
+	t, err := template.New("branch").Funcs(branchFuncs).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing branch template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering branch template: %w", err)
+	}
+	var parts []string
+	for _, part := range strings.Split(strings.Join(strings.Fields(buf.String()), "-"), "/") {
+		if part = strings.Trim(part, "-"); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	name := strings.Join(parts, "/")
+	if name == "" {
+		return "", fmt.Errorf("branch template %q gives an empty name", tmpl)
+	}
+	return name, nil
+}
+
+// Slug lowercases s and keeps only ASCII letters and digits, joining
+// words with hyphens, e.g. "Fix: login (SSO) fails!" becomes
+// "fix-login-sso-fails". Long slugs are cut at a word boundary.
+func Slug(s string) string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			word.WriteRune(r)
+		} else {
+			flush()
+		}
+	}
+	flush()
+
+	slug := ""
+	for _, w := range words {
+		next := w
+		if slug != "" {
+			next = slug + "-" + w
+		}
+		if len(next) > maxSlugLength {
+			if slug == "" {
+				slug = w[:maxSlugLength]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
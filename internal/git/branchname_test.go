@@ -0,0 +1,56 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBranchName(t *testing.T) {
+	data := BranchData{Key: "PROJ-123", Summary: "Add login page", Type: "story", Project: "PROJ"}
+	tests := []struct {
+		name    string
+		tmpl    string
+		data    BranchData
+		want    string
+		wantErr bool
+	}{
+		{"default", "", data, "story/PROJ-123-add-login-page", false},
+		{"key only", "{{.Key}}", data, "PROJ-123", false},
+		{"lower", "{{lower .Key}}-{{slug .Summary}}", data, "proj-123-add-login-page", false},
+		{"empty type drops its segment", "{{.Type}}/{{.Key}}", BranchData{Key: "PROJ-1"}, "PROJ-1", false},
+		{"whitespace becomes hyphens", "{{.Project}} {{.Key}}", data, "PROJ-PROJ-123", false},
+		{"empty summary", "", BranchData{Key: "PROJ-1", Type: "bug"}, "bug/PROJ-1", false},
+		{"empty result", "{{.Type}}", BranchData{}, "", true},
+		{"unknown field", "{{.Nope}}", data, "", true},
+		{"bad syntax", "{{.Key", data, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BranchName(tt.tmpl, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BranchName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BranchName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Fix: login (SSO) fails!", "fix-login-sso-fails"},
+		{"  spaces   everywhere ", "spaces-everywhere"},
+		{"Ünïcode only", "n-code-only"},
+		{"", ""},
+		{strings.Repeat("word ", 20), strings.TrimSuffix(strings.Repeat("word-", 10), "-")},
+		{strings.Repeat("x", 60), strings.Repeat("x", maxSlugLength)},
+	}
+	for _, tt := range tests {
+		if got := Slug(tt.in); got != tt.want {
+			t.Errorf("Slug(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package git runs the git command line for the CLI's git integration.
+// Every command runs in Repo.Dir, so a Repo can point at any working tree,
+// such as a temporary repository.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRepository means the directory isn't inside a git working tree.
+var ErrNotRepository = errors.New("not a git repository")
+
+type Repo struct {
+	// Dir is the top of the working tree; empty means the current directory.
+	Dir string
+}
+
+// Open returns the repository containing dir ("" for the current
+// directory).
+func Open(dir string) (*Repo, error) {
+	top, err := (&Repo{Dir: dir}).run("rev-parse", "--show-toplevel")
+	if err != nil {
+		if strings.Contains(err.Error(), "not a git repository") {
+			return nil, ErrNotRepository
+		}
+		return nil, err
+	}
+	return &Repo{Dir: top}, nil
+}
+
+// run runs git with args and returns its trimmed output. A failure is
+// reported with git's own message.
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return "", fmt.Errorf("running git: %w", err)
+		}
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = exitErr.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], strings.TrimPrefix(message, "fatal: "))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// CurrentBranch returns the checked out branch, or "" when HEAD is
+// detached.
+func (r *Repo) CurrentBranch() (string, error) {
+	name, err := r.run("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		if _, headErr := r.run("rev-parse", "--verify", "HEAD"); headErr == nil {
+			return "", nil
+		}
+		return "", err
+	}
+	return name, nil
+}
+
+// BranchExists reports whether a local branch exists.
+func (r *Repo) BranchExists(name string) (bool, error) {
+	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+name)
+	cmd.Dir = r.Dir
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("running git: %w", err)
+	}
+	return true, nil
+}
+
+// CreateBranch creates name at base, or at HEAD when base is empty.
+func (r *Repo) CreateBranch(name, base string) error {
+	args := []string{"branch", "--", name}
+	if base != "" {
+		args = append(args, base)
+	}
+	_, err := r.run(args...)
+	return err
+}
+
+func (r *Repo) Checkout(name string) error {
+	_, err := r.run("checkout", name, "--")
+	return err
+}
+
+// CheckBranchName reports whether name is a valid branch name.
+func (r *Repo) CheckBranchName(name string) error {
+	if _, err := r.run("check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("%q is not a valid branch name", name)
+	}
+	return nil
+}
+
+// LastHeadAction returns what last moved HEAD according to its reflog,
+// e.g. "commit", "commit (amend)", "rebase (pick)" or "cherry-pick", or ""
+// when there is no reflog.
+func (r *Repo) LastHeadAction() (string, error) {
+	out, err := r.run("reflog", "-1", "--format=%gs", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	action, _, _ := strings.Cut(out, ":")
+	return action, nil
+}
+
+// Rebasing reports whether a rebase (or git am) is in progress.
+func (r *Repo) Rebasing() (bool, error) {
+	for _, name := range []string{"rebase-merge", "rebase-apply"} {
+		dir, err := r.run("rev-parse", "--git-path", name)
+		if err != nil {
+			return false, err
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(r.Dir, dir)
+		}
+		if _, err := os.Stat(dir); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
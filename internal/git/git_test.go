@@ -0,0 +1,163 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepo creates a repository with one commit on main.
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	repo := &Repo{Dir: dir}
+	mustRun(t, repo, "init", "--quiet", "--initial-branch=main")
+	commitFile(t, repo, "README", "first")
+	return repo
+}
+
+func mustRun(t *testing.T, repo *Repo, args ...string) string {
+	t.Helper()
+	out, err := repo.run(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func commitFile(t *testing.T, repo *Repo, name, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(repo.Dir, name), []byte(message), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mustRun(t, repo, "add", name)
+	mustRun(t, repo, "commit", "--quiet", "-m", message)
+}
+
+func TestLastHeadAction(t *testing.T) {
+	repo := newTestRepo(t)
+	check := func(want string) {
+		t.Helper()
+		got, err := repo.LastHeadAction()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("LastHeadAction() = %q, want %q", got, want)
+		}
+	}
+
+	check("commit (initial)")
+	commitFile(t, repo, "a", "PROJ-1: second")
+	check("commit")
+	mustRun(t, repo, "commit", "--quiet", "--amend", "-m", "PROJ-1: reworded")
+	check("commit (amend)")
+
+	mustRun(t, repo, "checkout", "--quiet", "-b", "side", "HEAD~1")
+	mustRun(t, repo, "cherry-pick", "main")
+	check("cherry-pick")
+}
+
+func TestRebasing(t *testing.T) {
+	repo := newTestRepo(t)
+	if rebasing, err := repo.Rebasing(); err != nil || rebasing {
+		t.Fatalf("Rebasing() = %v, %v before a rebase", rebasing, err)
+	}
+
+	// Two branches changing the same file stop the rebase on a conflict.
+	commitFile(t, repo, "README", "main")
+	mustRun(t, repo, "checkout", "--quiet", "-b", "side", "HEAD~1")
+	commitFile(t, repo, "README", "side")
+	if _, err := repo.run("rebase", "main"); err == nil {
+		t.Fatal("rebase didn't stop on the conflict")
+	}
+	if rebasing, err := repo.Rebasing(); err != nil || !rebasing {
+		t.Errorf("Rebasing() = %v, %v during a rebase", rebasing, err)
+	}
+}
+
+func TestCurrentBranch(t *testing.T) {
+	repo := newTestRepo(t)
+	branch, err := repo.CurrentBranch()
+	if err != nil || branch != "main" {
+		t.Fatalf("CurrentBranch() = %q, %v, want main", branch, err)
+	}
+
+	mustRun(t, repo, "checkout", "--quiet", "--detach")
+	if branch, err = repo.CurrentBranch(); err != nil || branch != "" {
+		t.Errorf("CurrentBranch() on a detached HEAD = %q, %v, want empty", branch, err)
+	}
+}
+
+func TestCurrentBranchOutsideRepo(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.Dir = t.TempDir()
+	if _, err := repo.CurrentBranch(); err == nil {
+		t.Error("CurrentBranch() outside a repository succeeded")
+	}
+}
+
+func TestCreateBranch(t *testing.T) {
+	repo := newTestRepo(t)
+	first := mustRun(t, repo, "rev-parse", "HEAD")
+	commitFile(t, repo, "second", "second")
+
+	if err := repo.CreateBranch("story/PROJ-1-x", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CreateBranch("bug/PROJ-2-y", first); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustRun(t, repo, "rev-parse", "story/PROJ-1-x"), mustRun(t, repo, "rev-parse", "HEAD"); got != want {
+		t.Errorf("branch without a base is at %s, want HEAD %s", got, want)
+	}
+	if got := mustRun(t, repo, "rev-parse", "bug/PROJ-2-y"); got != first {
+		t.Errorf("branch with a base is at %s, want %s", got, first)
+	}
+
+	for name, want := range map[string]bool{"story/PROJ-1-x": true, "bug/PROJ-2-y": true, "PROJ-3": false} {
+		if exists, err := repo.BranchExists(name); err != nil || exists != want {
+			t.Errorf("BranchExists(%q) = %v, %v, want %v", name, exists, err, want)
+		}
+	}
+
+	if err := repo.CreateBranch("story/PROJ-1-x", ""); err == nil {
+		t.Error("CreateBranch() overwrote an existing branch")
+	}
+	if err := repo.CreateBranch("-d", ""); err == nil {
+		t.Error("CreateBranch() took a name starting with - as an option")
+	}
+	if err := repo.Checkout("story/PROJ-1-x"); err != nil {
+		t.Fatal(err)
+	}
+	if branch, _ := repo.CurrentBranch(); branch != "story/PROJ-1-x" {
+		t.Errorf("CurrentBranch() after Checkout = %q", branch)
+	}
+}
+
+func TestCheckBranchName(t *testing.T) {
+	repo := newTestRepo(t)
+	for name, valid := range map[string]bool{
+		"story/PROJ-1-add-login": true,
+		"PROJ-1":                 true,
+		"has space":              false,
+		"double..dot":            false,
+		"ends/":                  false,
+		"tilde~1":                false,
+	} {
+		if err := repo.CheckBranchName(name); (err == nil) != valid {
+			t.Errorf("CheckBranchName(%q) = %v, want valid %v", name, err, valid)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hookMarker identifies hooks written by InstallHook, so they can be
+// replaced and removed without touching anyone else's.
+const hookMarker = "# Installed by jira-cli; 'jira hooks uninstall' removes it."
+
+// ErrForeignHook means a hook exists that InstallHook didn't write.
+var ErrForeignHook = errors.New("a hook that jira didn't install is in the way")
+
+// HooksDir returns the directory git runs hooks from, which honours
+// core.hooksPath.
+func (r *Repo) HooksDir() (string, error) {
+	dir, err := r.run("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.Dir, dir)
+	}
+	return dir, nil
+}
+
+// InstallHook writes the named hook (e.g. "commit-msg") as a shell script
+// running command. A hook that jira didn't install is only replaced when
+// force is set. It returns the hook's path.
+func (r *Repo) InstallHook(name, command string, force bool) (string, error) {
+	dir, err := r.HooksDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if !force {
+		ours, err := isOurHook(path)
+		if err != nil {
+			return "", err
+		}
+		if !ours {
+			return "", fmt.Errorf("%w: %s (use --force to replace it)", ErrForeignHook, path)
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating hooks directory: %w", err)
+	}
+	script := "#!/bin/sh\n" + hookMarker + "\n" + command + "\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return "", fmt.Errorf("writing hook: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file.
+	if err := os.Chmod(path, 0o755); err != nil {
+		return "", fmt.Errorf("making hook executable: %w", err)
+	}
+	return path, nil
+}
+
+// RemoveHook deletes the named hook if jira installed it, reporting
+// whether there was one.
+func (r *Repo) RemoveHook(name string) (bool, error) {
+	dir, err := r.HooksDir()
+	if err != nil {
+		return false, err
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	ours, err := isOurHook(path)
+	if err != nil {
+		return false, err
+	}
+	if !ours {
+		return false, fmt.Errorf("%w: %s", ErrForeignHook, path)
+	}
+	if err := os.Remove(path); err != nil {
+		return false, fmt.Errorf("removing hook: %w", err)
+	}
+	return true, nil
+}
+
+// isOurHook reports whether path is missing or holds a hook jira wrote.
+func isOurHook(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading hook: %w", err)
+	}
+	return strings.Contains(string(data), hookMarker), nil
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultKeyPattern finds "PROJ-123" style ticket keys in branch names.
+const DefaultKeyPattern = `[A-Za-z][A-Za-z0-9_]*-[0-9]+`
+
+// CompileKeyPattern compiles a ticket key pattern, DefaultKeyPattern when
+// empty. A pattern with a capture group takes the key from its first group.
+func CompileKeyPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		pattern = DefaultKeyPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid branch_key_regex %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// TicketKeys returns the upper-cased ticket keys pattern finds in branch,
+// in order. Whatever doesn't look like PROJ-123 is skipped.
+func TicketKeys(branch string, pattern *regexp.Regexp) []string {
+	var keys []string
+	for _, match := range pattern.FindAllStringSubmatch(branch, -1) {
+		key := match[0]
+		if len(match) > 1 {
+			key = match[1]
+		}
+		key = strings.ToUpper(key)
+		if ProjectOf(key) != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ProjectOf returns the project part of a ticket key like "PROJ-123", or
+// "" if key isn't one.
+func ProjectOf(key string) string {
+	i := strings.LastIndex(key, "-")
+	if i <= 0 || i == len(key)-1 {
+		return ""
+	}
+	for _, r := range key[i+1:] {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return key[:i]
+}
+
+// messageKey matches ticket keys as written in commit messages, which
+// unlike branch names keep them upper-case.
+var messageKey = regexp.MustCompile(`\b[A-Z][A-Z0-9_]*-[0-9]+\b`)
+
+// MessageKeys returns the ticket keys mentioned in a commit message, each
+// once, in order.
+func MessageKeys(message string) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, key := range messageKey.FindAllString(message, -1) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
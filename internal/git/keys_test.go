@@ -0,0 +1,75 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTicketKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		branch  string
+		pattern string
+		want    []string
+	}{
+		{"typed branch", "story/PROJ-123-add-login", "", []string{"PROJ-123"}},
+		{"lower case", "proj-7-fix", "", []string{"PROJ-7"}},
+		{"one-letter project", "feature/A-1", "", []string{"A-1"}},
+		{"several keys", "PROJ-1-and-OPS-22", "", []string{"PROJ-1", "OPS-22"}},
+		{"no key", "main", "", nil},
+		{"capture group", "ticket_42", `ticket_([0-9]+)`, nil},
+		{"capture group with project", "feat/x/ABC-9", `/([A-Z]+-[0-9]+)$`, []string{"ABC-9"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := CompileKeyPattern(tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := TicketKeys(tt.branch, pattern); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TicketKeys(%q) = %q, want %q", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileKeyPatternRejectsBadRegex(t *testing.T) {
+	if _, err := CompileKeyPattern("(["); err == nil {
+		t.Error("CompileKeyPattern accepted an invalid pattern")
+	}
+}
+
+func TestProjectOf(t *testing.T) {
+	tests := map[string]string{
+		"PROJ-123":   "PROJ",
+		"MY-PROJ-1":  "MY-PROJ",
+		"PROJ-":      "",
+		"-1":         "",
+		"PROJ-12a":   "",
+		"PROJ":       "",
+		"ABC_DEF-77": "ABC_DEF",
+	}
+	for key, want := range tests {
+		if got := ProjectOf(key); got != want {
+			t.Errorf("ProjectOf(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestMessageKeys(t *testing.T) {
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"PROJ-1: fix login", []string{"PROJ-1"}},
+		{"Fix PROJ-1 and OPS-2, see PROJ-1", []string{"PROJ-1", "OPS-2"}},
+		{"lower proj-1 is not a key", nil},
+		{"utf-8 and sha-256", nil},
+		{"Merge branch 'story/PROJ-9-x'", []string{"PROJ-9"}},
+	}
+	for _, tt := range tests {
+		if got := MessageKeys(tt.message); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MessageKeys(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
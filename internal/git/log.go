@@ -0,0 +1,119 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field and record separators of the log format, which can't appear in
+// commit messages.
+const (
+	fieldSep  = "\x1f"
+	recordSep = "\x1e"
+)
+
+// logFormat prints what parseCommits reads.
+const logFormat = "%H" + fieldSep + "%an" + fieldSep + "%at" + fieldSep + "%s" + fieldSep + "%b" + recordSep
+
+type Commit struct {
+	SHA     string
+	Author  string
+	Date    time.Time
+	Subject string
+	Body    string
+}
+
+// Short returns the abbreviated SHA.
+func (c Commit) Short() string {
+	if len(c.SHA) > 7 {
+		return c.SHA[:7]
+	}
+	return c.SHA
+}
+
+// Message returns the subject and body.
+func (c Commit) Message() string {
+	if c.Body == "" {
+		return c.Subject
+	}
+	return c.Subject + "\n\n" + c.Body
+}
+
+// Head returns the commit HEAD points at.
+func (r *Repo) Head() (*Commit, error) {
+	out, err := r.run("log", "-1", "--format="+logFormat, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	commits, err := parseCommits(out)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("HEAD has no commit")
+	}
+	return &commits[0], nil
+}
+
+// CommitsMentioning returns the commits whose message mentions the ticket
+// key, newest first: those reachable from HEAD, or from every branch when
+// all is set. A limit of 0 means no limit.
+func (r *Repo) CommitsMentioning(key string, all bool, limit int) ([]Commit, error) {
+	args := []string{"log", "--format=" + logFormat, "--regexp-ignore-case", "--fixed-strings", "--grep=" + key}
+	if all {
+		args = append(args, "--all")
+	}
+	out, err := r.run(args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not have any commits") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	commits, err := parseCommits(out)
+	if err != nil {
+		return nil, err
+	}
+
+	// git only matched the text, so PROJ-12 also found PROJ-123.
+	mention := regexp.MustCompile(`(?i)(^|[^A-Za-z0-9_])` + regexp.QuoteMeta(key) + `($|[^0-9])`)
+	var found []Commit
+	for _, commit := range commits {
+		if mention.MatchString(commit.Message()) {
+			found = append(found, commit)
+			if len(found) == limit {
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+func parseCommits(out string) ([]Commit, error) {
+	var commits []Commit
+	for _, record := range strings.Split(out, recordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.SplitN(record, fieldSep, 5)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected git log output %q", record)
+		}
+		seconds, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected commit date %q", fields[2])
+		}
+		commits = append(commits, Commit{
+			SHA:     fields[0],
+			Author:  fields[1],
+			Date:    time.Unix(seconds, 0),
+			Subject: fields[3],
+			Body:    strings.TrimSpace(fields[4]),
+		})
+	}
+	return commits, nil
+}
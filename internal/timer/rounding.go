@@ -0,0 +1,93 @@
+package timer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Rounding modes for Rule.
+const (
+	RoundUp      = "up"
+	RoundNearest = "nearest"
+	RoundDown    = "down"
+)
+
+// Rule rounds elapsed time before it's logged, e.g. up to the next 15
+// minutes. Jira only records whole minutes, so the step is at least one.
+type Rule struct {
+	Mode string
+	Step time.Duration
+}
+
+// DefaultRule rounds up to the next whole minute.
+var DefaultRule = Rule{Mode: RoundUp, Step: time.Minute}
+
+// ParseRule reads the timer_rounding and timer_round_to settings; empty
+// values take the defaults.
+func ParseRule(mode, step string) (Rule, error) {
+	rule := DefaultRule
+	if mode != "" {
+		rule.Mode = strings.ToLower(mode)
+	}
+	switch rule.Mode {
+	case RoundUp, RoundNearest, RoundDown:
+	default:
+		return Rule{}, fmt.Errorf("invalid timer_rounding %q: use up, nearest or down", mode)
+	}
+
+	if step != "" {
+		d, err := time.ParseDuration(step)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid timer_round_to %q: use a duration such as 15m", step)
+		}
+		if d < time.Minute || d%time.Minute != 0 {
+			return Rule{}, fmt.Errorf("invalid timer_round_to %q: use whole minutes", step)
+		}
+		rule.Step = d
+	}
+	return rule, nil
+}
+
+// Apply rounds d to a multiple of the rule's step. Rounding up or to the
+// nearest step never returns zero for a timer that ran at all, so a short
+// task still gets logged; rounding down may.
+func (r Rule) Apply(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	var rounded time.Duration
+	switch r.Mode {
+	case RoundDown:
+		return d.Truncate(r.Step)
+	case RoundNearest:
+		rounded = d.Round(r.Step)
+	default:
+		rounded = d.Truncate(r.Step)
+		if rounded < d {
+			rounded += r.Step
+		}
+	}
+	if rounded == 0 {
+		rounded = r.Step
+	}
+	return rounded
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%s to %s", r.Mode, FormatJira(r.Step))
+}
+
+// FormatJira renders d in Jira's duration syntax using hours and minutes,
+// e.g. "1h 15m".
+func FormatJira(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh %dm", h, m)
+}
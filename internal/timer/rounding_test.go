@@ -0,0 +1,72 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		mode, step string
+		want       Rule
+		wantErr    bool
+	}{
+		{"", "", DefaultRule, false},
+		{"Nearest", "15m", Rule{Mode: RoundNearest, Step: 15 * time.Minute}, false},
+		{"down", "1h", Rule{Mode: RoundDown, Step: time.Hour}, false},
+		{"sideways", "", Rule{}, true},
+		{"", "15", Rule{}, true},
+		{"", "30s", Rule{}, true},
+		{"", "90s", Rule{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRule(tt.mode, tt.step)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRule(%q, %q) error = %v, wantErr %v", tt.mode, tt.step, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRule(%q, %q) = %+v, want %+v", tt.mode, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestRuleApply(t *testing.T) {
+	quarter := 15 * time.Minute
+	tests := []struct {
+		rule Rule
+		in   time.Duration
+		want time.Duration
+	}{
+		{DefaultRule, 10 * time.Second, time.Minute},
+		{DefaultRule, 61 * time.Second, 2 * time.Minute},
+		{DefaultRule, 0, 0},
+		{Rule{Mode: RoundUp, Step: quarter}, 16 * time.Minute, 30 * time.Minute},
+		{Rule{Mode: RoundUp, Step: quarter}, 30 * time.Minute, 30 * time.Minute},
+		{Rule{Mode: RoundNearest, Step: quarter}, 22 * time.Minute, quarter},
+		{Rule{Mode: RoundNearest, Step: quarter}, 23 * time.Minute, 30 * time.Minute},
+		{Rule{Mode: RoundNearest, Step: quarter}, 2 * time.Minute, quarter},
+		{Rule{Mode: RoundDown, Step: quarter}, 29 * time.Minute, quarter},
+		{Rule{Mode: RoundDown, Step: quarter}, 10 * time.Minute, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Apply(tt.in); got != tt.want {
+			t.Errorf("%v.Apply(%v) = %v, want %v", tt.rule, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatJira(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                             "0m",
+		45 * time.Minute:              "45m",
+		2 * time.Hour:                 "2h",
+		time.Hour + 15*time.Minute:    "1h 15m",
+		26*time.Hour + 30*time.Second: "26h",
+	}
+	for d, want := range tests {
+		if got := FormatJira(d); got != want {
+			t.Errorf("FormatJira(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
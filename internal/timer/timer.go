@@ -0,0 +1,91 @@
+// Package timer keeps track of the work timer started by `jira start
+// --timer`. The running timer is stored in a small JSON file under the user
+// config directory, so it survives new shells and reboots.
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/fileutil"
+)
+
+const stateFileName = "timer.json"
+
+// State is a running timer.
+type State struct {
+	Key     string    `json:"key"`
+	Profile string    `json:"profile"`
+	Started time.Time `json:"started"`
+}
+
+// Elapsed returns how long the timer has been running at now.
+func (s *State) Elapsed(now time.Time) time.Duration {
+	return now.Sub(s.Started)
+}
+
+// Path returns the location of the state file.
+func Path() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating config directory: %w", err)
+	}
+	return filepath.Join(base, "jira-cli", stateFileName), nil
+}
+
+// Load returns the running timer, or nil if none is running.
+func Load() (*State, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading timer: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("reading timer %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save records state as the running timer, replacing any other.
+func Save(state *State) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding timer: %w", err)
+	}
+	if err := fileutil.WriteAtomic(path, data, 0600); err != nil {
+		return fmt.Errorf("saving timer: %w", err)
+	}
+	return nil
+}
+
+// Clear stops the running timer, if any.
+func Clear() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("clearing timer: %w", err)
+	}
+	return nil
+}
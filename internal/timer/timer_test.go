@@ -0,0 +1,41 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSaveLoadClear(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+
+	if state, err := Load(); err != nil || state != nil {
+		t.Fatalf("Load() with no timer = %v, %v", state, err)
+	}
+
+	started := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	if err := Save(&State{Key: "PROJ-1", Profile: "work", Started: started}); err != nil {
+		t.Fatal(err)
+	}
+	state, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Key != "PROJ-1" || state.Profile != "work" || !state.Started.Equal(started) {
+		t.Errorf("Load() = %+v", state)
+	}
+	if got := state.Elapsed(started.Add(90 * time.Minute)); got != 90*time.Minute {
+		t.Errorf("Elapsed() = %v", got)
+	}
+
+	if err := Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Clear(); err != nil {
+		t.Errorf("Clear() with no timer: %v", err)
+	}
+	if state, err := Load(); err != nil || state != nil {
+		t.Errorf("Load() after Clear() = %v, %v", state, err)
+	}
+}
@@ -0,0 +1,317 @@
+// Package tui is the full-screen terminal interface behind `jira tui`: an
+// issue list for a JQL query with a detail pane and single-key actions.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+)
+
+type Options struct {
+	Client  *api.Client
+	JiraURL string
+	JQL     string
+	Limit   int
+}
+
+type mode int
+
+const (
+	modeList mode = iota
+	modeFilter
+	modePrompt
+	modeMenu
+	modeHelp
+)
+
+// detailDelay is how long the cursor rests on an issue before its
+// comments are fetched, so scrolling through the list stays cheap.
+const detailDelay = 200 * time.Millisecond
+
+// sizePollInterval is how often the terminal size is checked for resizes.
+const sizePollInterval = 250 * time.Millisecond
+
+// App holds the interface's state. It is only touched by the event loop;
+// background requests hand their results back through results.
+type App struct {
+	opts Options
+	ctx  context.Context
+
+	width, height int
+	mode          mode
+
+	issues  []api.Issue
+	visible []int // indexes into issues that match the filter
+	cursor  int   // index into visible
+	offset  int   // first visible row on screen
+	filter  string
+	loading bool
+
+	// detailOpen shows the selected issue full screen instead of the list.
+	detailOpen   bool
+	details      map[string]*detail
+	detailScroll int
+
+	prompt *prompt
+	menu   *menu
+
+	status    string
+	statusErr bool
+
+	results chan func()
+	quit    bool
+}
+
+// detail is an issue as fetched on its own, with its comments.
+type detail struct {
+	issue    *api.Issue
+	comments []api.Comment
+	loading  bool
+	err      error
+}
+
+// prompt is a one-line text input on the status line.
+type prompt struct {
+	label    string
+	input    []rune
+	onSubmit func(string)
+}
+
+// menu is a list to pick from, drawn above the status line.
+type menu struct {
+	title    string
+	items    []string
+	cursor   int
+	onSelect func(int)
+}
+
+// Run shows the interface until the user quits or ctx is cancelled.
+func Run(ctx context.Context, opts Options) error {
+	t, err := OpenTerminal()
+	if err != nil {
+		return err
+	}
+	defer t.Restore()
+
+	a := &App{
+		opts:    opts,
+		ctx:     ctx,
+		details: make(map[string]*detail),
+		results: make(chan func(), 16),
+	}
+	a.width, a.height = t.Size()
+
+	keys := make(chan Key)
+	go t.ReadKeys(keys)
+	ticker := time.NewTicker(sizePollInterval)
+	defer ticker.Stop()
+
+	a.refresh()
+	redraw := true
+	for !a.quit {
+		if redraw {
+			a.draw(t)
+		}
+		redraw = true
+
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			a.handleKey(key)
+		case apply := <-a.results:
+			apply()
+		case <-ticker.C:
+			width, height := t.Size()
+			if width == a.width && height == a.height {
+				redraw = false
+				continue
+			}
+			a.width, a.height = width, height
+			a.scrollToCursor()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// async runs work in the background; the function it returns is applied
+// on the event loop.
+func (a *App) async(work func() func()) {
+	go func() {
+		a.results <- work()
+	}()
+}
+
+func (a *App) setStatus(format string, args ...interface{}) {
+	a.status = fmt.Sprintf(format, args...)
+	a.statusErr = false
+}
+
+func (a *App) setError(err error) {
+	a.status = err.Error()
+	a.statusErr = true
+}
+
+// refresh runs the query again, keeping the cursor on the same issue.
+func (a *App) refresh() {
+	if a.issues != nil {
+		a.opts.Client.DropCachedSearches()
+	}
+	a.loading = true
+	a.setStatus("Loading...")
+	a.async(func() func() {
+		results, err := a.opts.Client.SearchIssuesContext(a.ctx, a.opts.JQL, a.opts.Limit)
+		return func() {
+			a.loading = false
+			if err != nil {
+				a.setError(err)
+				return
+			}
+			key := a.selectedKey()
+			a.issues = results.Issues
+			a.details = make(map[string]*detail)
+			a.applyFilter()
+			a.selectKey(key)
+			a.setStatus("%d ticket(s)", len(a.issues))
+			a.loadDetailLater()
+		}
+	})
+}
+
+// reloadIssue fetches one issue and its comments again after an action
+// changed it, updating its row in the list.
+func (a *App) reloadIssue(key string) {
+	a.details[key] = &detail{loading: true}
+	a.async(func() func() {
+		issue, err := a.opts.Client.GetIssueContext(a.ctx, key)
+		var comments []api.Comment
+		if err == nil {
+			comments, err = a.opts.Client.GetCommentsContext(a.ctx, key)
+		}
+		return func() {
+			if err != nil {
+				a.details[key] = &detail{err: err}
+				return
+			}
+			a.details[key] = &detail{issue: issue, comments: comments}
+			for i := range a.issues {
+				if a.issues[i].Key == key {
+					a.issues[i] = *issue
+				}
+			}
+			a.applyFilter()
+			a.selectKey(key)
+		}
+	})
+}
+
+// loadDetailLater fetches the selected issue's details once the cursor
+// has rested on it for detailDelay.
+func (a *App) loadDetailLater() {
+	key := a.selectedKey()
+	if key == "" || a.details[key] != nil {
+		return
+	}
+	go func() {
+		time.Sleep(detailDelay)
+		a.results <- func() {
+			if a.selectedKey() == key && a.details[key] == nil {
+				a.reloadIssue(key)
+			}
+		}
+	}()
+}
+
+func (a *App) selected() *api.Issue {
+	if a.cursor < 0 || a.cursor >= len(a.visible) {
+		return nil
+	}
+	return &a.issues[a.visible[a.cursor]]
+}
+
+func (a *App) selectedKey() string {
+	if issue := a.selected(); issue != nil {
+		return issue.Key
+	}
+	return ""
+}
+
+func (a *App) selectKey(key string) {
+	for i, index := range a.visible {
+		if a.issues[index].Key == key {
+			a.cursor = i
+			break
+		}
+	}
+	a.scrollToCursor()
+}
+
+// applyFilter keeps the issues matching every word of the filter in their
+// key, summary, status, type or assignee.
+func (a *App) applyFilter() {
+	words := strings.Fields(strings.ToLower(a.filter))
+	a.visible = a.visible[:0]
+	for i := range a.issues {
+		if matchesAll(filterText(&a.issues[i]), words) {
+			a.visible = append(a.visible, i)
+		}
+	}
+	if a.cursor >= len(a.visible) {
+		a.cursor = len(a.visible) - 1
+	}
+	if a.cursor < 0 {
+		a.cursor = 0
+	}
+	a.scrollToCursor()
+}
+
+func filterText(issue *api.Issue) string {
+	parts := []string{issue.Key, issue.Fields.Summary, issue.Fields.Status.Name, issue.Fields.IssueType.Name}
+	if issue.Fields.Assignee != nil {
+		parts = append(parts, issue.Fields.Assignee.DisplayName)
+	}
+	return strings.ToLower(strings.Join(parts, " "))
+}
+
+func matchesAll(text string, words []string) bool {
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *App) moveCursor(delta int) {
+	a.cursor += delta
+	if a.cursor >= len(a.visible) {
+		a.cursor = len(a.visible) - 1
+	}
+	if a.cursor < 0 {
+		a.cursor = 0
+	}
+	a.detailScroll = 0
+	a.scrollToCursor()
+	a.loadDetailLater()
+}
+
+// scrollToCursor moves the list so the cursor is on screen.
+func (a *App) scrollToCursor() {
+	rows := a.listRows()
+	if a.cursor < a.offset {
+		a.offset = a.cursor
+	}
+	if a.cursor >= a.offset+rows {
+		a.offset = a.cursor - rows + 1
+	}
+	if a.offset < 0 {
+		a.offset = 0
+	}
+}
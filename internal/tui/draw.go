@@ -0,0 +1,301 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"github.com/danielyan21/JiraCLI/internal/ui"
+)
+
+// splitWidth is the narrowest terminal that shows the detail pane next to
+// the list; narrower ones open it full screen with Enter.
+const splitWidth = 100
+
+var helpText = []string{
+	"Navigation",
+	"  j/k, ↓/↑        move through the list",
+	"  g/G, Home/End   first / last ticket",
+	"  PgUp/PgDn       page through the list",
+	"  Enter, l        open the ticket full screen (Esc, h to go back)",
+	"  J/K             scroll the detail pane",
+	"  n/p             next / previous ticket in the full-screen view",
+	"",
+	"Tickets",
+	"  t               transition to another status",
+	"  a               assign (@me or a user)",
+	"  c               add a comment",
+	"  o               open in the browser",
+	"",
+	"List",
+	"  /               filter as you type (Enter keeps it, Esc clears it)",
+	"  r               run the query again",
+	"  q, Ctrl+C       quit",
+}
+
+// bodyRows is the height between the title bar and the status line.
+func (a *App) bodyRows() int {
+	if rows := a.height - 2; rows > 1 {
+		return rows
+	}
+	return 1
+}
+
+// listRows is how many tickets fit under the list's column headings.
+func (a *App) listRows() int {
+	if rows := a.bodyRows() - 1; rows > 1 {
+		return rows
+	}
+	return 1
+}
+
+func (a *App) draw(t *Terminal) {
+	lines := make([]string, 0, a.height)
+	lines = append(lines, a.titleBar())
+
+	rows := a.bodyRows()
+	var body []string
+	switch {
+	case a.mode == modeHelp:
+		body = helpText
+	case a.detailOpen:
+		body = a.scrolled(a.detailLines(a.width), rows)
+	case a.width >= splitWidth:
+		listWidth := a.width * 45 / 100
+		detailWidth := a.width - listWidth - 3
+		list := a.listLines(listWidth)
+		detail := a.scrolled(a.detailLines(detailWidth), rows)
+		for i := 0; i < rows; i++ {
+			left, right := "", ""
+			if i < len(list) {
+				left = list[i]
+			}
+			if i < len(detail) {
+				right = detail[i]
+			}
+			body = append(body, fit(left, listWidth)+" │ "+right)
+		}
+	default:
+		body = a.listLines(a.width)
+	}
+	if a.menu != nil {
+		body = a.overlayMenu(body, rows)
+	}
+
+	for i := 0; i < rows; i++ {
+		line := ""
+		if i < len(body) {
+			line = body[i]
+		}
+		lines = append(lines, fit(line, a.width)+resetStyle)
+	}
+	lines = append(lines, a.statusLine())
+
+	var buf bytes.Buffer
+	buf.WriteString(cursorHome)
+	buf.WriteString(strings.Join(lines, "\r\n"))
+	t.Write(buf.Bytes())
+}
+
+func (a *App) titleBar() string {
+	count := fmt.Sprintf("%d ticket(s)", len(a.issues))
+	if a.filter != "" {
+		count = fmt.Sprintf("%d of %d", len(a.visible), len(a.issues))
+	}
+	if a.loading {
+		count = "loading..."
+	}
+	left := fit(" jira · "+a.opts.JQL, a.width-len(count)-2)
+	return reverseVideo + fit(left+" "+count+" ", a.width) + resetStyle
+}
+
+func (a *App) statusLine() string {
+	c := ui.NewColorFuncs()
+	switch a.mode {
+	case modeFilter:
+		return fit("/"+a.filter+"█", a.width)
+	case modePrompt:
+		return fit(a.prompt.label+string(a.prompt.input)+"█", a.width)
+	}
+
+	hint := "? help  q quit"
+	status := a.status
+	if a.filter != "" && status == "" {
+		status = "filter: " + a.filter
+	}
+	if a.statusErr {
+		status = c.Red(status)
+	}
+	left := fit(status, a.width-len(hint)-1)
+	return left + " " + c.Gray(hint)
+}
+
+func (a *App) listLines(width int) []string {
+	c := ui.NewColorFuncs()
+	lines := []string{c.Bold(fmt.Sprintf("%-10s %-14s %-14s %s", "KEY", "STATUS", "ASSIGNEE", "SUMMARY"))}
+	if len(a.visible) == 0 && !a.loading {
+		lines = append(lines, c.Gray("No tickets"))
+		return lines
+	}
+
+	end := a.offset + a.listRows()
+	if end > len(a.visible) {
+		end = len(a.visible)
+	}
+	for i := a.offset; i < end; i++ {
+		issue := &a.issues[a.visible[i]]
+		assignee := "Unassigned"
+		if issue.Fields.Assignee != nil {
+			assignee = issue.Fields.Assignee.DisplayName
+		}
+		key := fmt.Sprintf("%-10s", issue.Key)
+		status := fmt.Sprintf("%-14s", fit(issue.Fields.Status.Name, 14))
+		assignee = fmt.Sprintf("%-14s", fit(assignee, 14))
+
+		if i == a.cursor {
+			row := fmt.Sprintf("%s %s %s %s", key, status, assignee, issue.Fields.Summary)
+			lines = append(lines, reverseVideo+fit(row, width)+resetStyle)
+			continue
+		}
+		statusColor := ui.GetStatusColor(issue.Fields.Status.Name)
+		lines = append(lines, fmt.Sprintf("%s %s %s %s",
+			c.Cyan(key), statusColor(status), c.Yellow(assignee), issue.Fields.Summary))
+	}
+	return lines
+}
+
+// detailLines lays out the selected ticket: its fields, the description
+// and comments rendered like `jira view` does, wrapped to width.
+func (a *App) detailLines(width int) []string {
+	issue := a.selected()
+	if issue == nil {
+		return nil
+	}
+	d := a.details[issue.Key]
+	if d != nil && d.issue != nil {
+		issue = d.issue
+	}
+
+	c := ui.NewColorFuncs()
+	f := &issue.Fields
+	lines := []string{c.Cyan(issue.Key) + " " + c.Gray(f.IssueType.Name)}
+	for _, line := range wrap(f.Summary, width) {
+		lines = append(lines, c.Bold(line))
+	}
+
+	assignee := c.Gray("Unassigned")
+	if f.Assignee != nil {
+		assignee = c.Yellow(f.Assignee.DisplayName)
+	}
+	lines = append(lines, "",
+		c.Bold("Status: ")+ui.GetStatusColor(f.Status.Name)(f.Status.Name)+
+			"   "+c.Bold("Priority: ")+ui.GetPriorityColor(f.Priority.Name)(f.Priority.Name),
+		c.Bold("Assignee: ")+assignee)
+	if f.Reporter != nil {
+		lines = append(lines, c.Bold("Reporter: ")+f.Reporter.DisplayName)
+	}
+	if !f.Updated.IsZero() {
+		lines = append(lines, c.Bold("Updated: ")+c.Gray(f.Updated.Format("2006-01-02 15:04")))
+	}
+
+	lines = append(lines, "", c.Bold("Description:"))
+	if text := ui.RenderRichText(f.Description, width-2); strings.TrimSpace(text) != "" {
+		lines = append(lines, indent(text)...)
+	} else {
+		lines = append(lines, "  "+c.Gray("(No description)"))
+	}
+
+	lines = append(lines, "")
+	switch {
+	case d == nil || d.loading:
+		lines = append(lines, c.Gray("Loading comments..."))
+	case d.err != nil:
+		lines = append(lines, c.Red("Error: "+d.err.Error()))
+	default:
+		lines = append(lines, commentLines(d.comments, width, c)...)
+	}
+	return lines
+}
+
+func commentLines(comments []api.Comment, width int, c *ui.ColorFuncs) []string {
+	lines := []string{c.Bold(fmt.Sprintf("Comments (%d):", len(comments)))}
+	for _, comment := range comments {
+		lines = append(lines, "",
+			c.Yellow(comment.Author.DisplayName)+" "+c.Gray(comment.Created.Format("2006-01-02 15:04")))
+		if text := ui.RenderRichText(comment.Body, width-2); strings.TrimSpace(text) != "" {
+			lines = append(lines, indent(text)...)
+		} else {
+			lines = append(lines, "  "+c.Gray("(Empty comment)"))
+		}
+	}
+	return lines
+}
+
+// scrolled returns the rows of lines shown at the detail scroll position,
+// keeping the last page on screen when scrolled past the end.
+func (a *App) scrolled(lines []string, rows int) []string {
+	if max := len(lines) - rows; a.detailScroll > max {
+		a.detailScroll = max
+	}
+	if a.detailScroll < 0 {
+		a.detailScroll = 0
+	}
+	return lines[a.detailScroll:]
+}
+
+// overlayMenu draws the open menu over the bottom of the body.
+func (a *App) overlayMenu(body []string, rows int) []string {
+	m := a.menu
+	c := ui.NewColorFuncs()
+	box := []string{strings.Repeat("─", a.width), c.Bold(m.title)}
+	for i, item := range m.items {
+		line := fmt.Sprintf("  %d %s", i+1, item)
+		if i >= 9 {
+			line = "    " + item
+		}
+		if i == m.cursor {
+			line = reverseVideo + fit(line, a.width) + resetStyle
+		}
+		box = append(box, line)
+	}
+	if len(box) > rows {
+		box = box[len(box)-rows:]
+	}
+
+	out := make([]string, rows)
+	copy(out, body)
+	copy(out[rows-len(box):], box)
+	return out
+}
+
+func indent(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "  " + line
+		}
+	}
+	return lines
+}
+
+// wrap breaks plain text into lines of at most width runes at spaces.
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case visibleWidth(line)+1+visibleWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
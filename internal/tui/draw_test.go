@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"", 10, nil},
+		{"one two three", 20, []string{"one two three"}},
+		{"one two three", 7, []string{"one two", "three"}},
+		{"  spaced   out  ", 20, []string{"spaced out"}},
+		{"héllo wörld", 5, []string{"héllo", "wörld"}},
+		{"a longwordthatdoesnotfit b", 5, []string{"a", "longwordthatdoesnotfit", "b"}},
+		{"a b", 0, []string{"a", "b"}},
+		{"a b", -1, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		if got := wrap(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+func (a *App) handleKey(key Key) {
+	if key.Code == KeyCtrlC {
+		a.quit = true
+		return
+	}
+
+	switch a.mode {
+	case modeFilter:
+		a.filterKey(key)
+	case modePrompt:
+		a.promptKey(key)
+	case modeMenu:
+		a.menuKey(key)
+	case modeHelp:
+		a.mode = modeList
+	default:
+		if a.detailOpen {
+			a.detailKey(key)
+		} else {
+			a.listKey(key)
+		}
+	}
+}
+
+func (a *App) listKey(key Key) {
+	switch key.Code {
+	case KeyUp:
+		a.moveCursor(-1)
+	case KeyDown:
+		a.moveCursor(1)
+	case KeyPageUp:
+		a.moveCursor(-a.listRows())
+	case KeyPageDown:
+		a.moveCursor(a.listRows())
+	case KeyHome:
+		a.moveCursor(-len(a.visible))
+	case KeyEnd:
+		a.moveCursor(len(a.visible))
+	case KeyEnter, KeyRight:
+		if a.selected() != nil {
+			a.detailOpen = true
+			a.detailScroll = 0
+		}
+	case KeyEsc:
+		a.filter = ""
+		a.applyFilter()
+	case KeyRune:
+		a.listCommand(key.Rune)
+	}
+}
+
+func (a *App) listCommand(r rune) {
+	switch r {
+	case 'q':
+		a.quit = true
+	case 'j':
+		a.moveCursor(1)
+	case 'k':
+		a.moveCursor(-1)
+	case 'g':
+		a.moveCursor(-len(a.visible))
+	case 'G':
+		a.moveCursor(len(a.visible))
+	case 'J':
+		a.detailScroll++
+	case 'K':
+		a.scrollDetail(-1)
+	case 'l':
+		a.listKey(Key{Code: KeyEnter})
+	case '/':
+		a.mode = modeFilter
+	case 'r':
+		a.refresh()
+	case '?':
+		a.mode = modeHelp
+	default:
+		a.issueCommand(r)
+	}
+}
+
+// issueCommand runs the actions on the selected issue, which work from
+// both the list and the detail view.
+func (a *App) issueCommand(r rune) {
+	issue := a.selected()
+	if issue == nil {
+		return
+	}
+	switch r {
+	case 't':
+		a.chooseTransition(issue.Key)
+	case 'a':
+		a.askAssignee(issue.Key)
+	case 'c':
+		a.askComment(issue.Key)
+	case 'o':
+		url := fmt.Sprintf("%s/browse/%s", strings.TrimRight(a.opts.JiraURL, "/"), issue.Key)
+		if err := openBrowser(url); err != nil {
+			a.setError(fmt.Errorf("opening browser: %w", err))
+			return
+		}
+		a.setStatus("Opened %s", url)
+	}
+}
+
+func (a *App) detailKey(key Key) {
+	switch key.Code {
+	case KeyEsc, KeyLeft:
+		a.detailOpen = false
+	case KeyUp:
+		a.scrollDetail(-1)
+	case KeyDown:
+		a.detailScroll++
+	case KeyPageUp:
+		a.scrollDetail(-a.bodyRows())
+	case KeyPageDown:
+		a.detailScroll += a.bodyRows()
+	case KeyHome:
+		a.detailScroll = 0
+	case KeyRune:
+		switch key.Rune {
+		case 'q', 'h':
+			a.detailOpen = false
+		case 'j':
+			a.detailScroll++
+		case 'k':
+			a.scrollDetail(-1)
+		case 'g':
+			a.detailScroll = 0
+		case 'n':
+			a.moveCursor(1)
+		case 'p':
+			a.moveCursor(-1)
+		case '?':
+			a.mode = modeHelp
+		default:
+			a.issueCommand(key.Rune)
+		}
+	}
+}
+
+func (a *App) scrollDetail(delta int) {
+	a.detailScroll += delta
+	if a.detailScroll < 0 {
+		a.detailScroll = 0
+	}
+}
+
+// filterKey edits the filter, narrowing the list as it's typed. Enter
+// keeps the filter; Esc drops it.
+func (a *App) filterKey(key Key) {
+	switch key.Code {
+	case KeyEnter, KeyUp, KeyDown:
+		a.mode = modeList
+		if key.Code != KeyEnter {
+			a.listKey(key)
+		}
+		return
+	case KeyEsc:
+		a.filter = ""
+		a.mode = modeList
+	case KeyBackspace:
+		if a.filter == "" {
+			a.mode = modeList
+			return
+		}
+		runes := []rune(a.filter)
+		a.filter = string(runes[:len(runes)-1])
+	case KeyCtrlU:
+		a.filter = ""
+	case KeyRune:
+		a.filter += string(key.Rune)
+	default:
+		return
+	}
+	a.applyFilter()
+	a.loadDetailLater()
+}
+
+func (a *App) promptKey(key Key) {
+	p := a.prompt
+	switch key.Code {
+	case KeyEnter:
+		a.mode = modeList
+		a.prompt = nil
+		p.onSubmit(strings.TrimSpace(string(p.input)))
+	case KeyEsc:
+		a.mode = modeList
+		a.prompt = nil
+		a.setStatus("Cancelled")
+	case KeyBackspace:
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+		}
+	case KeyCtrlU:
+		p.input = nil
+	case KeyRune:
+		p.input = append(p.input, key.Rune)
+	}
+}
+
+func (a *App) menuKey(key Key) {
+	m := a.menu
+	switch key.Code {
+	case KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case KeyDown:
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case KeyEnter:
+		a.closeMenu()
+		m.onSelect(m.cursor)
+	case KeyEsc:
+		a.closeMenu()
+		a.setStatus("Cancelled")
+	case KeyRune:
+		switch r := key.Rune; {
+		case r == 'j':
+			a.menuKey(Key{Code: KeyDown})
+		case r == 'k':
+			a.menuKey(Key{Code: KeyUp})
+		case r == 'q':
+			a.menuKey(Key{Code: KeyEsc})
+		case r >= '1' && r <= '9' && int(r-'1') < len(m.items):
+			a.closeMenu()
+			m.onSelect(int(r - '1'))
+		}
+	}
+}
+
+func (a *App) openMenu(m *menu) {
+	a.menu = m
+	a.mode = modeMenu
+}
+
+func (a *App) closeMenu() {
+	a.menu = nil
+	a.mode = modeList
+}
+
+func (a *App) chooseTransition(key string) {
+	a.setStatus("Fetching transitions for %s...", key)
+	a.async(func() func() {
+		transitions, err := a.opts.Client.GetTransitionsContext(a.ctx, key)
+		return func() {
+			if err != nil {
+				a.setError(fmt.Errorf("fetching transitions: %w", err))
+				return
+			}
+			if len(transitions.Transitions) == 0 {
+				a.setStatus("%s has no transitions available", key)
+				return
+			}
+
+			items := make([]string, len(transitions.Transitions))
+			for i, t := range transitions.Transitions {
+				items[i] = t.Name
+				if !strings.EqualFold(t.Name, t.To.Name) {
+					items[i] += " → " + t.To.Name
+				}
+			}
+			a.setStatus("")
+			a.openMenu(&menu{
+				title: "Move " + key + " to:",
+				items: items,
+				onSelect: func(i int) {
+					a.transition(key, transitions.Transitions[i].ID, transitions.Transitions[i].To.Name)
+				},
+			})
+		}
+	})
+}
+
+func (a *App) transition(key, transitionID, status string) {
+	a.setStatus("Moving %s to %s...", key, status)
+	a.async(func() func() {
+		err := a.opts.Client.TransitionIssueContext(a.ctx, key, transitionID)
+		return func() {
+			if err != nil {
+				a.setError(fmt.Errorf("moving %s: %w", key, err))
+				return
+			}
+			a.setStatus("Moved %s to %s", key, status)
+			a.reloadIssue(key)
+		}
+	})
+}
+
+func (a *App) askAssignee(key string) {
+	a.openPrompt("Assign "+key+" to (@me or user): ", "@me", func(assignee string) {
+		if assignee == "" {
+			a.setStatus("Cancelled")
+			return
+		}
+		a.setStatus("Assigning %s to %s...", key, assignee)
+		a.async(func() func() {
+			err := a.opts.Client.AssignIssueContext(a.ctx, key, assignee)
+			return func() {
+				if err != nil {
+					a.setError(fmt.Errorf("assigning %s: %w", key, err))
+					return
+				}
+				a.setStatus("Assigned %s to %s", key, assignee)
+				a.reloadIssue(key)
+			}
+		})
+	})
+}
+
+func (a *App) askComment(key string) {
+	a.openPrompt("Comment on "+key+": ", "", func(comment string) {
+		if comment == "" {
+			a.setStatus("Cancelled")
+			return
+		}
+		a.setStatus("Adding comment to %s...", key)
+		a.async(func() func() {
+			err := a.opts.Client.AddCommentContext(a.ctx, key, comment)
+			return func() {
+				if err != nil {
+					a.setError(fmt.Errorf("commenting on %s: %w", key, err))
+					return
+				}
+				a.setStatus("Comment added to %s", key)
+				a.reloadIssue(key)
+			}
+		})
+	})
+}
+
+func (a *App) openPrompt(label, initial string, onSubmit func(string)) {
+	a.prompt = &prompt{label: label, input: []rune(initial), onSubmit: onSubmit}
+	a.mode = modePrompt
+}
+
+// openBrowser opens url with the desktop's default handler.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ANSI sequences for the full-screen display.
+const (
+	enterAltScreen = "\x1b[?1049h"
+	exitAltScreen  = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	cursorHome     = "\x1b[H"
+	clearLine      = "\x1b[K"
+	reverseVideo   = "\x1b[7m"
+	resetStyle     = "\x1b[0m"
+)
+
+// Terminal is the screen in raw mode on the alternate buffer, so the
+// shell's scrollback is left as it was once Restore runs.
+type Terminal struct {
+	in, out *os.File
+	state   *term.State
+}
+
+// ErrNotTerminal means stdin or stdout isn't a terminal, e.g. in a pipe.
+var ErrNotTerminal = errors.New("the terminal UI needs an interactive terminal")
+
+func OpenTerminal() (*Terminal, error) {
+	in, out := os.Stdin, os.Stdout
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return nil, ErrNotTerminal
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	out.WriteString(enterAltScreen + hideCursor)
+	return &Terminal{in: in, out: out, state: state}, nil
+}
+
+// Restore leaves the alternate screen and raw mode.
+func (t *Terminal) Restore() {
+	t.out.WriteString(resetStyle + showCursor + exitAltScreen)
+	term.Restore(int(t.in.Fd()), t.state)
+}
+
+// Size returns the terminal's width and height, with a usable fallback
+// if it can't be read.
+func (t *Terminal) Size() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+func (t *Terminal) Write(b []byte) (int, error) {
+	return t.out.Write(b)
+}
+
+// KeyCode identifies a key; printable characters are KeyRune with the
+// character in Key.Rune.
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyEnter
+	KeyEsc
+	KeyBackspace
+	KeyTab
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+	KeyCtrlC
+	KeyCtrlU
+	KeyUnknown
+)
+
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// escapeKeys maps the CSI and SS3 sequences terminals send for special
+// keys, without the leading ESC.
+var escapeKeys = map[string]KeyCode{
+	"[A": KeyUp, "[B": KeyDown, "[C": KeyRight, "[D": KeyLeft,
+	"OA": KeyUp, "OB": KeyDown, "OC": KeyRight, "OD": KeyLeft,
+	"[H": KeyHome, "[F": KeyEnd, "OH": KeyHome, "OF": KeyEnd,
+	"[1~": KeyHome, "[4~": KeyEnd, "[7~": KeyHome, "[8~": KeyEnd,
+	"[5~": KeyPageUp, "[6~": KeyPageDown,
+}
+
+// ReadKeys sends the keys typed until reading stdin fails.
+func (t *Terminal) ReadKeys(keys chan<- Key) {
+	buf := make([]byte, 256)
+	for {
+		n, err := t.in.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// parseKeys splits one read into keys. A read holds several keys when
+// text is pasted; a lone ESC is the Escape key.
+func parseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			code, size := parseEscape(b[1:])
+			keys = append(keys, Key{Code: code})
+			b = b[1+size:]
+			continue
+		case c == '\r' || c == '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+		case c == '\t':
+			keys = append(keys, Key{Code: KeyTab})
+		case c == 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+		case c == 0x15:
+			keys = append(keys, Key{Code: KeyCtrlU})
+		case c < 0x20:
+			keys = append(keys, Key{Code: KeyUnknown})
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, Key{Code: KeyRune, Rune: r})
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// parseEscape reads the sequence after an ESC, returning the key and how
+// many bytes it used.
+func parseEscape(b []byte) (KeyCode, int) {
+	if len(b) == 0 || (b[0] != '[' && b[0] != 'O') {
+		return KeyEsc, 0
+	}
+	// A sequence ends at its first byte in the range @ to ~.
+	for i := 1; i < len(b); i++ {
+		if b[i] >= 0x40 && b[i] <= 0x7e {
+			if code, ok := escapeKeys[string(b[:i+1])]; ok {
+				return code, i + 1
+			}
+			return KeyUnknown, i + 1
+		}
+	}
+	// A CSI sequence cut off by the end of the read is dropped rather than
+	// typed in as text.
+	if b[0] == '[' {
+		return KeyUnknown, len(b)
+	}
+	return KeyEsc, 0
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []Key
+	}{
+		{"letter", "j", []Key{{Code: KeyRune, Rune: 'j'}}},
+		{"pasted text", "ab", []Key{{Code: KeyRune, Rune: 'a'}, {Code: KeyRune, Rune: 'b'}}},
+		{"multi-byte rune", "é→", []Key{{Code: KeyRune, Rune: 'é'}, {Code: KeyRune, Rune: '→'}}},
+		{"enter", "\r", []Key{{Code: KeyEnter}}},
+		{"backspace", "\x7f\x08", []Key{{Code: KeyBackspace}, {Code: KeyBackspace}}},
+		{"control keys", "\t\x03\x15\x01", []Key{{Code: KeyTab}, {Code: KeyCtrlC}, {Code: KeyCtrlU}, {Code: KeyUnknown}}},
+		{"lone escape", "\x1b", []Key{{Code: KeyEsc}}},
+		{"escape then letter", "\x1bq", []Key{{Code: KeyEsc}, {Code: KeyRune, Rune: 'q'}}},
+		{"arrows", "\x1b[A\x1bOB", []Key{{Code: KeyUp}, {Code: KeyDown}}},
+		{"page keys", "\x1b[5~\x1b[6~", []Key{{Code: KeyPageUp}, {Code: KeyPageDown}}},
+		{"home and end", "\x1b[H\x1b[4~", []Key{{Code: KeyHome}, {Code: KeyEnd}}},
+		{"unknown sequence", "\x1b[15~x", []Key{{Code: KeyUnknown}, {Code: KeyRune, Rune: 'x'}}},
+		{"cut-off sequence", "\x1b[1;", []Key{{Code: KeyUnknown}}},
+		{"cut-off after introducer", "\x1b[", []Key{{Code: KeyUnknown}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.in)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeys(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
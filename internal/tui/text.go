@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// visibleWidth counts the runes of s that show on screen, skipping ANSI
+// escape sequences.
+func visibleWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		width++
+	}
+	return width
+}
+
+// fit cuts or pads s to exactly width visible columns, keeping its
+// colors. A cut line ends with "…".
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	visible := visibleWidth(s)
+	if visible <= width {
+		return s + strings.Repeat(" ", width-visible)
+	}
+
+	var b strings.Builder
+	shown := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		if shown == width-1 {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		i += size
+		shown++
+	}
+	b.WriteString("…" + resetStyle)
+	return b.String()
+}
+
+// escapeLen returns the length of the CSI sequence s starts with, or 0.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,64 @@
+package tui
+
+import "testing"
+
+const red = "\x1b[31m"
+
+func TestVisibleWidth(t *testing.T) {
+	tests := map[string]int{
+		"":                         0,
+		"abc":                      3,
+		"héllo":                    5,
+		red + "red" + resetStyle:   3,
+		"cut" + "\x1b[3":           3,
+		"\x1b[1;31m→" + resetStyle: 1,
+	}
+	for s, want := range tests {
+		if got := visibleWidth(s); got != want {
+			t.Errorf("visibleWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"abc", 5, "abc  "},
+		{"abc", 3, "abc"},
+		{"abcdef", 4, "abc…" + resetStyle},
+		{"héllo wörld", 3, "hé…" + resetStyle},
+		{red + "abcdef" + resetStyle, 3, red + "ab" + "…" + resetStyle},
+		{"abc", 1, "…" + resetStyle},
+		{"abc", 0, ""},
+		{"abc", -2, ""},
+	}
+	for _, tt := range tests {
+		got := fit(tt.in, tt.width)
+		if got != tt.want {
+			t.Errorf("fit(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		if tt.width > 0 && visibleWidth(got) != tt.width {
+			t.Errorf("fit(%q, %d) is %d columns wide", tt.in, tt.width, visibleWidth(got))
+		}
+	}
+}
+
+func TestEscapeLen(t *testing.T) {
+	tests := map[string]int{
+		"abc":            0,
+		"\x1b":           0,
+		"\x1bx":          0,
+		red + "x":        len(red),
+		"\x1b[?1049h":    len("\x1b[?1049h"),
+		"\x1b[12":        len("\x1b[12"),
+		"x" + resetStyle: 0,
+	}
+	for s, want := range tests {
+		if got := escapeLen(s); got != want {
+			t.Errorf("escapeLen(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"golang.org/x/term"
+)
+
+type AttachmentJSON struct {
+	ID       string    `json:"id"`
+	Filename string    `json:"filename"`
+	Size     int64     `json:"size"`
+	MimeType string    `json:"mimeType"`
+	Author   *UserJSON `json:"author"`
+	Created  time.Time `json:"created"`
+}
+
+func NewAttachmentJSON(attachment *api.Attachment) AttachmentJSON {
+	return AttachmentJSON{
+		ID:       attachment.ID,
+		Filename: attachment.Filename,
+		Size:     attachment.Size,
+		MimeType: attachment.MimeType,
+		Author:   NewUserJSON(attachment.Author),
+		Created:  attachment.Created.Time,
+	}
+}
+
+// FormatSize renders a byte count with a binary unit, e.g. "1.5 MB".
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func RenderAttachments(issueKey string, attachments []api.Attachment) {
+	if jsonOutput {
+		docs := make([]AttachmentJSON, 0, len(attachments))
+		for i := range attachments {
+			docs = append(docs, NewAttachmentJSON(&attachments[i]))
+		}
+		PrintJSON(docs)
+		return
+	}
+
+	if len(attachments) == 0 {
+		fmt.Printf("\nNo attachments on %s.\n", issueKey)
+		return
+	}
+	printAttachments(attachments, NewColorFuncs())
+}
+
+func printAttachments(attachments []api.Attachment, c *ColorFuncs) {
+	fmt.Printf("\n%s (%d)\n", c.Bold("Attachments:"), len(attachments))
+	for _, attachment := range attachments {
+		author := ""
+		if attachment.Author != nil {
+			author = attachment.Author.DisplayName
+		}
+		fmt.Printf("  %s %s %s %s %s\n",
+			c.Cyan(fmt.Sprintf("%-8s", attachment.ID)),
+			fmt.Sprintf("%-36s", Truncate(attachment.Filename, 36)),
+			fmt.Sprintf("%9s", FormatSize(attachment.Size)),
+			c.Yellow(Truncate(author, 20)),
+			c.Gray(attachment.Created.Format("2006-01-02")),
+		)
+	}
+}
+
+// progressThreshold is the smallest transfer worth a progress line.
+const progressThreshold = 1 << 20
+
+// ProgressReader reports how much of a transfer has been read on stderr,
+// redrawing one line at most a few times a second. It stays silent unless
+// stderr is a terminal and the transfer is large, so piped output and
+// scripts are unaffected.
+type ProgressReader struct {
+	r       io.Reader
+	label   string
+	total   int64
+	read    int64
+	enabled bool
+	drawn   time.Time
+}
+
+// NewProgressReader wraps r, a transfer of total bytes (0 or less if
+// unknown), labelled with e.g. a file name.
+func NewProgressReader(r io.Reader, label string, total int64) *ProgressReader {
+	enabled := (total <= 0 || total >= progressThreshold) && term.IsTerminal(int(os.Stderr.Fd()))
+	return &ProgressReader{r: r, label: label, total: total, enabled: enabled}
+}
+
+func (p *ProgressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.enabled && time.Since(p.drawn) >= 200*time.Millisecond {
+		p.draw()
+	}
+	return n, err
+}
+
+// Done clears the progress line.
+func (p *ProgressReader) Done() {
+	if p.enabled && !p.drawn.IsZero() {
+		fmt.Fprintf(os.Stderr, "\r%s\r", strings.Repeat(" ", 70))
+	}
+}
+
+func (p *ProgressReader) draw() {
+	p.drawn = time.Now()
+	label := Truncate(p.label, 30)
+	if p.total <= 0 {
+		fmt.Fprintf(os.Stderr, "\r  %-30s %10s", label, FormatSize(p.read))
+		return
+	}
+	percent := p.read * 100 / p.total
+	fmt.Fprintf(os.Stderr, "\r  %-30s %10s / %-10s %3d%%", label, FormatSize(p.read), FormatSize(p.total), percent)
+}
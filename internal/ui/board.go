@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+	"golang.org/x/term"
+)
+
+// BoardView is a kanban board ready to draw: its columns in order and the
+// issues to place in them, already in rank order.
+type BoardView struct {
+	Title   string
+	Columns []BoardColumn
+	Issues  []api.Issue
+
+	// LaneOf names an issue's swimlane; nil draws no swimlanes. Lanes are
+	// sorted by name, except that names in parentheses, such as
+	// "(Unassigned)", go last.
+	LaneOf func(*api.Issue) string
+}
+
+// BoardColumn is one column of a board. Statuses holds the IDs or names of
+// the statuses it shows; Min and Max are WIP limits, zero when unset.
+type BoardColumn struct {
+	Name     string
+	Statuses []string
+	Min, Max int
+}
+
+type BoardJSON struct {
+	Title   string            `json:"title"`
+	Columns []BoardColumnJSON `json:"columns"`
+}
+
+type BoardColumnJSON struct {
+	Name   string          `json:"name"`
+	Count  int             `json:"count"`
+	Min    int             `json:"min,omitempty"`
+	Max    int             `json:"max,omitempty"`
+	Issues []BoardCardJSON `json:"issues"`
+}
+
+type BoardCardJSON struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+	Lane     string `json:"lane,omitempty"`
+}
+
+// otherColumn collects issues in statuses the board has no column for.
+const otherColumn = "Other"
+
+// placeIssues sorts the issues into the board's columns, adding an
+// otherColumn at the end if any issue fits none of them.
+func (view *BoardView) placeIssues() ([]BoardColumn, [][]*api.Issue) {
+	columns := view.Columns
+	cards := make([][]*api.Issue, len(columns))
+	var other []*api.Issue
+
+	for i := range view.Issues {
+		issue := &view.Issues[i]
+		placed := false
+		for j, column := range columns {
+			if column.holds(issue.Fields.Status) {
+				cards[j] = append(cards[j], issue)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			other = append(other, issue)
+		}
+	}
+
+	if len(other) > 0 {
+		columns = append(columns[:len(columns):len(columns)], BoardColumn{Name: otherColumn})
+		cards = append(cards, other)
+	}
+	return columns, cards
+}
+
+func (column *BoardColumn) holds(status api.Status) bool {
+	for _, s := range column.Statuses {
+		if s == status.ID || strings.EqualFold(s, status.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func RenderBoard(view *BoardView) {
+	columns, cards := view.placeIssues()
+	if jsonOutput {
+		renderBoardJSON(view, columns, cards)
+		return
+	}
+
+	c := NewColorFuncs()
+	fmt.Printf("\n%s\n\n", c.Bold(view.Title))
+	if len(columns) == 0 {
+		fmt.Println("No columns to show.")
+		return
+	}
+
+	const gap = "  "
+	width := (terminalWidth() - len(gap)*(len(columns)-1)) / len(columns)
+	if width < 14 {
+		width = 14
+	}
+
+	headers := make([]string, len(columns))
+	rules := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = columnHeader(column, len(cards[i]), width, c)
+		rules[i] = strings.Repeat("─", width)
+	}
+	fmt.Println(strings.Join(headers, gap))
+	fmt.Println(c.Gray(strings.Join(rules, gap)))
+
+	for _, lane := range boardLanes(view, cards) {
+		if lane.name != "" {
+			fmt.Printf("\n%s %s\n", c.Bold("▸ "+lane.name), c.Gray(fmt.Sprintf("(%d)", lane.count)))
+		}
+		for row := 0; row < lane.rows; row++ {
+			cells := make([]string, len(columns))
+			for i := range columns {
+				cells[i] = strings.Repeat(" ", width)
+				if row < len(lane.cards[i]) {
+					cells[i] = boardCard(lane.cards[i][row], width, c)
+				}
+			}
+			fmt.Println(strings.TrimRight(strings.Join(cells, gap), " "))
+		}
+	}
+
+	fmt.Printf("\n%s\n", c.Green(fmt.Sprintf("%d ticket(s)", len(view.Issues))))
+}
+
+type boardLane struct {
+	name  string
+	cards [][]*api.Issue
+	rows  int
+	count int
+}
+
+// boardLanes splits each column's cards by swimlane, keeping rank order
+// within a lane.
+func boardLanes(view *BoardView, cards [][]*api.Issue) []*boardLane {
+	lanes := map[string]*boardLane{}
+	var names []string
+	for i, column := range cards {
+		for _, issue := range column {
+			name := ""
+			if view.LaneOf != nil {
+				name = view.LaneOf(issue)
+			}
+			lane, ok := lanes[name]
+			if !ok {
+				lane = &boardLane{name: name, cards: make([][]*api.Issue, len(cards))}
+				lanes[name] = lane
+				names = append(names, name)
+			}
+			lane.cards[i] = append(lane.cards[i], issue)
+			lane.count++
+			if len(lane.cards[i]) > lane.rows {
+				lane.rows = len(lane.cards[i])
+			}
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		iLast, jLast := strings.HasPrefix(names[i], "("), strings.HasPrefix(names[j], "(")
+		if iLast != jLast {
+			return jLast
+		}
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+	ordered := make([]*boardLane, len(names))
+	for i, name := range names {
+		ordered[i] = lanes[name]
+	}
+	return ordered
+}
+
+// columnHeader renders "NAME count/max", red with a "!" when the column is
+// outside its WIP limits.
+func columnHeader(column BoardColumn, count, width int, c *ColorFuncs) string {
+	wip := strconv.Itoa(count)
+	if column.Max > 0 {
+		wip += "/" + strconv.Itoa(column.Max)
+	}
+	overLimit := (column.Max > 0 && count > column.Max) || (column.Min > 0 && count < column.Min)
+	if overLimit {
+		wip += "!"
+	}
+
+	name := fitWidth(strings.ToUpper(column.Name), width-len(wip)-1)
+	text := padWidth(name+" "+wip, width)
+	if overLimit {
+		return c.Red(text)
+	}
+	return GetStatusColor(column.Name)(text)
+}
+
+func boardCard(issue *api.Issue, width int, c *ColorFuncs) string {
+	key := fitWidth(issue.Key, width)
+	summary := fitWidth(issue.Fields.Summary, width-utf8.RuneCountInString(key)-1)
+	text := key
+	if summary != "" {
+		text += " " + summary
+	}
+	padding := width - utf8.RuneCountInString(text)
+	return c.Cyan(key) + strings.TrimPrefix(text, key) + strings.Repeat(" ", padding)
+}
+
+func renderBoardJSON(view *BoardView, columns []BoardColumn, cards [][]*api.Issue) {
+	doc := BoardJSON{Title: view.Title, Columns: make([]BoardColumnJSON, len(columns))}
+	for i, column := range columns {
+		doc.Columns[i] = BoardColumnJSON{Name: column.Name, Count: len(cards[i]), Min: column.Min, Max: column.Max,
+			Issues: make([]BoardCardJSON, 0, len(cards[i]))}
+		for _, issue := range cards[i] {
+			card := BoardCardJSON{Key: issue.Key, Summary: issue.Fields.Summary, Status: issue.Fields.Status.Name}
+			if issue.Fields.Assignee != nil {
+				card.Assignee = issue.Fields.Assignee.DisplayName
+			}
+			if view.LaneOf != nil {
+				card.Lane = view.LaneOf(issue)
+			}
+			doc.Columns[i].Issues = append(doc.Columns[i].Issues, card)
+		}
+	}
+	PrintJSON(doc)
+}
+
+// terminalWidth returns the width of the terminal on stdout, falling back
+// to $COLUMNS and then 120 when stdout isn't a terminal.
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 120
+}
+
+// fitWidth shortens s to at most width runes, marking the cut with "…".
+func fitWidth(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+func padWidth(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/cache"
+)
+
+type CacheStatsJSON struct {
+	Dir   string               `json:"dir"`
+	TTL   string               `json:"ttl"`
+	Kinds []CacheKindStatsJSON `json:"kinds"`
+}
+
+type CacheKindStatsJSON struct {
+	Kind    string     `json:"kind"`
+	Entries int        `json:"entries"`
+	Bytes   int64      `json:"bytes"`
+	Oldest  *time.Time `json:"oldest,omitempty"`
+	Newest  *time.Time `json:"newest,omitempty"`
+}
+
+type SyncJSON struct {
+	Dir      string   `json:"dir"`
+	Queries  []string `json:"queries"`
+	Issues   int      `json:"issues"`
+	Comments int      `json:"comments"`
+}
+
+func RenderCacheStats(c *cache.Cache, stats []cache.KindStats) {
+	if jsonOutput {
+		doc := CacheStatsJSON{Dir: c.Dir, TTL: c.TTL.String(), Kinds: make([]CacheKindStatsJSON, len(stats))}
+		for i, s := range stats {
+			doc.Kinds[i] = CacheKindStatsJSON{Kind: s.Kind, Entries: s.Entries, Bytes: s.Bytes}
+			if s.Entries > 0 {
+				oldest, newest := s.Oldest, s.Newest
+				doc.Kinds[i].Oldest, doc.Kinds[i].Newest = &oldest, &newest
+			}
+		}
+		PrintJSON(doc)
+		return
+	}
+
+	colors := NewColorFuncs()
+	fmt.Printf("\n%s %s\n", colors.Bold("Cache:"), c.Dir)
+	fmt.Printf("%s %s\n\n", colors.Bold("Fresh for:"), c.TTL)
+	fmt.Printf("%-10s %8s %10s  %s\n", "KIND", "ENTRIES", "SIZE", "STORED")
+	fmt.Println(strings.Repeat("-", 70))
+
+	var entries int
+	var bytes int64
+	for _, s := range stats {
+		stored := "-"
+		if s.Entries > 0 {
+			stored = fmt.Sprintf("%s to %s", s.Oldest.Format("2006-01-02 15:04"), s.Newest.Format("2006-01-02 15:04"))
+		}
+		fmt.Printf("%s %8d %10s  %s\n", colors.Cyan(fmt.Sprintf("%-10s", s.Kind)), s.Entries, FormatSize(s.Bytes), colors.Gray(stored))
+		entries += s.Entries
+		bytes += s.Bytes
+	}
+	fmt.Println(strings.Repeat("-", 70))
+	fmt.Printf("%-10s %8d %10s\n", "total", entries, FormatSize(bytes))
+}
@@ -1,17 +1,127 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+)
+
+// Exit codes let scripts tell failure classes apart without parsing output.
+const (
+	ExitError        = 1
+	ExitUnauthorized = 3
+	ExitForbidden    = 4
+	ExitNotFound     = 5
+	ExitInvalid      = 6
+	ExitRateLimited  = 7
+	ExitServerError  = 8
+	ExitInterrupted  = 130 // conventional 128+SIGINT
 )
 
 func FatalError(format string, args ...interface{}) {
+	if jsonOutput {
+		PrintJSON(ErrorJSON{Error: fmt.Sprintf(format, args...)})
+		os.Exit(ExitError)
+	}
 	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
-	os.Exit(1)
+	os.Exit(ExitError)
 }
 
 func FatalIfError(err error, message string) {
-	if err != nil {
+	if err == nil {
+		return
+	}
+
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		code := ExitCode(err)
+		if jsonOutput {
+			PrintJSON(ErrorJSON{Error: err.Error(), Message: message})
+			os.Exit(code)
+		}
+		if code == ExitInterrupted {
+			fmt.Fprintln(os.Stderr, "\nInterrupted")
+			os.Exit(code)
+		}
 		FatalError("%s: %v", message, err)
 	}
+
+	code := ExitCode(err)
+	if jsonOutput {
+		PrintJSON(ErrorJSON{
+			Error:      err.Error(),
+			Message:    message,
+			StatusCode: apiErr.StatusCode,
+			Fields:     apiErr.FieldErrors,
+		})
+		os.Exit(code)
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %s: %s\n", message, describeAPIError(apiErr))
+	for _, field := range apiErr.Fields() {
+		fmt.Fprintf(os.Stderr, "  %s: %s\n", field, apiErr.FieldErrors[field])
+	}
+	os.Exit(code)
+}
+
+// ExitCode maps an error to the process exit code for its failure class.
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case errors.Is(err, api.ErrUnauthorized):
+		return ExitUnauthorized
+	case errors.Is(err, api.ErrForbidden):
+		return ExitForbidden
+	case errors.Is(err, api.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, api.ErrBadRequest), errors.Is(err, api.ErrConflict):
+		return ExitInvalid
+	case errors.Is(err, api.ErrRateLimited):
+		return ExitRateLimited
+	case errors.Is(err, api.ErrServer):
+		return ExitServerError
+	}
+	return ExitError
+}
+
+// describeAPIError turns an API error into a one-line message for humans.
+// Field errors are printed separately by the caller.
+func describeAPIError(err *api.Error) string {
+	detail := ""
+	if len(err.Messages) > 0 {
+		detail = err.Messages[0]
+		for _, msg := range err.Messages[1:] {
+			detail += "; " + msg
+		}
+	}
+
+	var hint string
+	switch {
+	case errors.Is(err, api.ErrUnauthorized):
+		hint = "authentication failed; check your credentials or run 'jira init'"
+	case errors.Is(err, api.ErrForbidden):
+		hint = "you don't have permission to do that"
+	case errors.Is(err, api.ErrNotFound):
+		hint = "not found (check the key and that you have access to it)"
+	case errors.Is(err, api.ErrRateLimited):
+		hint = "Jira is rate limiting requests; try again shortly"
+	case errors.Is(err, api.ErrServer):
+		hint = fmt.Sprintf("Jira returned a server error (HTTP %d)", err.StatusCode)
+	case len(err.FieldErrors) > 0 && detail == "":
+		hint = "the request was rejected:"
+	}
+
+	switch {
+	case hint != "" && detail != "":
+		return hint + " - " + detail
+	case hint != "":
+		return hint
+	case detail != "":
+		return detail
+	}
+	return err.Error()
 }
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+)
+
+// FieldJSON is one extra field shown by `jira view --full`.
+type FieldJSON struct {
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+	Text  string          `json:"text"`
+}
+
+// hiddenFields are fields Jira returns that are noise in a field listing
+// or are shown in their own section.
+var hiddenFields = map[string]bool{
+	"aggregateprogress":        true,
+	"attachment":               true,
+	"comment":                  true,
+	"issuelinks":               true,
+	"lastViewed":               true,
+	"progress":                 true,
+	"statuscategorychangedate": true,
+	"thumbnail":                true,
+	"votes":                    true,
+	"watches":                  true,
+	"worklog":                  true,
+	"workratio":                true,
+}
+
+// issueFields returns the issue's untyped fields that have a value, named
+// from the field metadata and sorted by name.
+func issueFields(issue *api.Issue, meta []api.Field) []FieldJSON {
+	names := make(map[string]string, len(meta))
+	for _, field := range meta {
+		names[field.ID] = field.Name
+	}
+
+	var fields []FieldJSON
+	for id, raw := range issue.Fields.Other {
+		if hiddenFields[id] {
+			continue
+		}
+		text := FieldText(raw)
+		if text == "" {
+			continue
+		}
+		name := names[id]
+		if name == "" {
+			name = id
+		}
+		fields = append(fields, FieldJSON{ID: id, Name: name, Value: raw, Text: text})
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Name != fields[j].Name {
+			return fields[i].Name < fields[j].Name
+		}
+		return fields[i].ID < fields[j].ID
+	})
+	return fields
+}
+
+// FieldText renders a raw field value for humans: option values, user and
+// version names, numbers without trailing zeros, lists joined by commas,
+// and rich-text fields as plain text.
+func FieldText(raw json.RawMessage) string {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return string(raw)
+	}
+	return fieldValueText(value)
+}
+
+// sprintPattern pulls the name out of the toString() form Jira Server
+// returns for sprints: "com.atlassian.greenhopper...[id=1,name=Sprint 4,...]".
+var sprintPattern = regexp.MustCompile(`\[.*\bname=([^,\]]*)`)
+
+func fieldValueText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if m := sprintPattern.FindStringSubmatch(v); m != nil && strings.Contains(v, "greenhopper") {
+			return m[1]
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			if text := fieldValueText(item); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+		if v["type"] == "doc" {
+			return strings.TrimSpace(RichTextPlain(v))
+		}
+		for _, key := range []string{"displayName", "name", "value", "key", "title"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				if child, ok := v["child"].(map[string]interface{}); ok {
+					return s + " > " + fieldValueText(child)
+				}
+				return s
+			}
+		}
+		if id, ok := v["id"]; ok {
+			return fmt.Sprint(id)
+		}
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+	return fmt.Sprint(value)
+}
+
+func printIssueFields(fields []FieldJSON, c *ColorFuncs) {
+	fmt.Printf("\n%s\n", c.Bold("Fields:"))
+	if len(fields) == 0 {
+		fmt.Printf("  %s\n", c.Gray("(No other fields set)"))
+		return
+	}
+
+	for _, field := range fields {
+		text := field.Text
+		if strings.Contains(text, "\n") {
+			fmt.Printf("  %s\n", c.Bold(field.Name+":"))
+			for _, line := range strings.Split(text, "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+			continue
+		}
+		fmt.Printf("  %s %s\n", c.Bold(field.Name+":"), text)
+	}
+}
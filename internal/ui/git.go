@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type BranchJSON struct {
+	Key      string   `json:"key"`
+	Branch   string   `json:"branch"`
+	Created  bool     `json:"created"`
+	Started  bool     `json:"started,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type CommitJSON struct {
+	SHA     string    `json:"sha"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+type CommitListJSON struct {
+	Key     string       `json:"key"`
+	Commits []CommitJSON `json:"commits"`
+}
+
+type HooksJSON struct {
+	Installed []string `json:"installed,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+}
+
+func RenderCommits(doc *CommitListJSON) {
+	if jsonOutput {
+		PrintJSON(doc)
+		return
+	}
+
+	if len(doc.Commits) == 0 {
+		fmt.Printf("\nNo commits mention %s.\n", doc.Key)
+		return
+	}
+
+	c := NewColorFuncs()
+	fmt.Printf("\n%s\n\n", c.Bold(fmt.Sprintf("Commits mentioning %s:", doc.Key)))
+	fmt.Printf("%-8s %-16s %-20s %s\n", "SHA", "DATE", "AUTHOR", "SUBJECT")
+	fmt.Println(strings.Repeat("-", 80))
+
+	for _, commit := range doc.Commits {
+		sha := commit.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		fmt.Printf("%s %s %s %s\n",
+			c.Cyan(fmt.Sprintf("%-8s", sha)),
+			c.Gray(commit.Date.Local().Format("2006-01-02 15:04")),
+			c.Yellow(fmt.Sprintf("%-20s", Truncate(commit.Author, 20))),
+			Truncate(commit.Subject, 60),
+		)
+	}
+	fmt.Printf("\n%d commit(s)\n", len(doc.Commits))
+}
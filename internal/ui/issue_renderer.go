@@ -8,6 +8,11 @@ import (
 )
 
 func RenderIssueList(results *api.SearchResults) {
+	if jsonOutput {
+		renderIssueListJSON(results)
+		return
+	}
+
 	if len(results.Issues) == 0 {
 		fmt.Println("\nNo tickets found.")
 		return
@@ -31,12 +36,28 @@ func RenderIssueList(results *api.SearchResults) {
 	fmt.Printf("\n%s\n", c.Green(fmt.Sprintf("Showing %d of %d total results", actualCount, totalCount)))
 }
 
-func RenderIssueDetail(issue *api.Issue, jiraURL string, comments []api.Comment) {
+// RenderIssueDetail prints one issue. When fieldMeta is non-nil (jira view
+// --full) every other field with a value is listed by name as well.
+func RenderIssueDetail(issue *api.Issue, jiraURL string, comments []api.Comment, fieldMeta []api.Field) {
+	if jsonOutput {
+		renderIssueDetailJSON(issue, jiraURL, comments, fieldMeta)
+		return
+	}
+
 	c := NewColorFuncs()
 
 	printIssueHeader(issue, c)
 	printIssueSummary(issue, c)
 	printIssueDescription(issue, c)
+	if len(issue.Fields.IssueLinks) > 0 {
+		printIssueLinks(issue.Fields.IssueLinks, c)
+	}
+	if fieldMeta != nil {
+		printIssueFields(issueFields(issue, fieldMeta), c)
+	}
+	if len(issue.Fields.Attachments) > 0 {
+		printAttachments(issue.Fields.Attachments, c)
+	}
 	printBrowserLink(issue, jiraURL, c)
 
 	if len(comments) > 0 {
@@ -113,61 +134,58 @@ func printIssueSummary(issue *api.Issue, c *ColorFuncs) {
 
 func printIssueDescription(issue *api.Issue, c *ColorFuncs) {
 	fmt.Printf("\n%s\n", c.Bold("Description:"))
-	if issue.Fields.Description != nil {
-		switch desc := issue.Fields.Description.(type) {
-		case string:
-			if desc != "" {
-				wrappedText := wrapText(desc, 78)
-				for _, line := range strings.Split(wrappedText, "\n") {
-					fmt.Printf("  %s\n", line)
-				}
-			} else {
-				fmt.Printf("  %s\n", c.Gray("(No description)"))
-			}
-		case map[string]interface{}:
-			// Try to extract text from ADF format
-			text := extractDescriptionFromADF(desc)
-			if text != "" {
-				wrappedText := wrapText(text, 78)
-				for _, line := range strings.Split(wrappedText, "\n") {
-					fmt.Printf("  %s\n", line)
-				}
-			} else {
-				fmt.Printf("  %s\n", c.Gray("(No description)"))
-			}
-		default:
-			fmt.Printf("  %s\n", c.Gray("(No description)"))
-		}
-	} else {
+	text := RenderRichText(issue.Fields.Description, 78)
+	if strings.TrimSpace(text) == "" {
 		fmt.Printf("  %s\n", c.Gray("(No description)"))
+		return
 	}
+	printIndented(text)
 }
 
-func extractDescriptionFromADF(adf map[string]interface{}) string {
-	var text string
+// descriptionText returns the description as plain text for both the v2
+// (wiki markup) and v3 (ADF) representations.
+func descriptionText(issue *api.Issue) string {
+	return RichTextPlain(issue.Fields.Description)
+}
 
-	if adf["type"] == "text" {
-		if textVal, ok := adf["text"].(string); ok {
-			return textVal
+func printIndented(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			fmt.Println()
+			continue
 		}
+		fmt.Printf("  %s\n", line)
 	}
+}
 
-	if content, ok := adf["content"].([]interface{}); ok {
-		for i, item := range content {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				itemText := extractDescriptionFromADF(itemMap)
-				if itemText != "" {
-					// Add spacing between paragraphs
-					if i > 0 && adf["type"] == "doc" {
-						text += "\n\n"
-					}
-					text += itemText
-				}
-			}
+// printIssueLinks lists linked issues grouped by relation, in the order
+// Jira returns them.
+func printIssueLinks(links []api.IssueLink, c *ColorFuncs) {
+	fmt.Printf("\n%s\n", c.Bold("Links:"))
+
+	var relations []string
+	grouped := make(map[string][]*api.LinkedIssue)
+	for _, link := range links {
+		relation, other := link.Relation()
+		if other == nil {
+			continue
 		}
+		if _, ok := grouped[relation]; !ok {
+			relations = append(relations, relation)
+		}
+		grouped[relation] = append(grouped[relation], other)
 	}
 
-	return text
+	for _, relation := range relations {
+		fmt.Printf("  %s\n", c.Gray(relation))
+		for _, other := range grouped[relation] {
+			statusColor := GetStatusColor(other.Fields.Status.Name)
+			fmt.Printf("    %s %s %s\n",
+				c.Cyan(fmt.Sprintf("%-10s", other.Key)),
+				statusColor(fmt.Sprintf("%-12s", other.Fields.Status.Name)),
+				Truncate(other.Fields.Summary, 50))
+		}
+	}
 }
 
 func printBrowserLink(issue *api.Issue, jiraURL string, c *ColorFuncs) {
@@ -190,42 +208,12 @@ func printComments(comments []api.Comment, c *ColorFuncs) {
 
 		fmt.Printf("\n%s %s\n", c.Yellow(comment.Author.DisplayName), c.Gray(comment.Created.Format("2006-01-02 15:04")))
 
-		bodyText := comment.GetBodyText()
-		if bodyText != "" {
-			wrappedText := wrapText(bodyText, 78)
-			for _, line := range strings.Split(wrappedText, "\n") {
-				fmt.Printf("  %s\n", line)
-			}
+		bodyText := RenderRichText(comment.Body, 78)
+		if strings.TrimSpace(bodyText) != "" {
+			printIndented(bodyText)
 		} else {
 			fmt.Printf("  %s\n", c.Gray("(Empty comment)"))
 		}
 		fmt.Println()
 	}
 }
-
-func wrapText(text string, width int) string {
-	words := strings.Fields(text)
-	if len(words) == 0 {
-		return text
-	}
-
-	var lines []string
-	var currentLine string
-
-	for _, word := range words {
-		if currentLine == "" {
-			currentLine = word
-		} else if len(currentLine)+1+len(word) <= width {
-			currentLine += " " + word
-		} else {
-			lines = append(lines, currentLine)
-			currentLine = word
-		}
-	}
-
-	if currentLine != "" {
-		lines = append(lines, currentLine)
-	}
-
-	return strings.Join(lines, "\n")
-}
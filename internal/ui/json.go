@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+)
+
+var jsonOutput bool
+
+// SetJSONOutput switches every renderer between human text and JSON documents.
+func SetJSONOutput(enabled bool) {
+	jsonOutput = enabled
+}
+
+func JSONOutput() bool {
+	return jsonOutput
+}
+
+// Progress prints status chatter such as "Fetching tickets...". In JSON mode
+// it goes to stderr so stdout only ever carries the JSON document.
+func Progress(format string, args ...interface{}) {
+	out := os.Stdout
+	if jsonOutput {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, format, args...)
+}
+
+// Result prints doc as JSON in JSON mode, otherwise the formatted message.
+func Result(doc interface{}, format string, args ...interface{}) {
+	if jsonOutput {
+		PrintJSON(doc)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+func PrintJSON(v interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: encoding JSON output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type UserJSON struct {
+	AccountID   string `json:"accountId,omitempty"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email,omitempty"`
+}
+
+type IssueJSON struct {
+	ID          string    `json:"id"`
+	Key         string    `json:"key"`
+	Summary     string    `json:"summary"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Priority    string    `json:"priority"`
+	Assignee    *UserJSON `json:"assignee"`
+	Reporter    *UserJSON `json:"reporter"`
+	Project     string    `json:"project"`
+	Description string    `json:"description"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+	URL         string    `json:"url,omitempty"`
+}
+
+type IssueListJSON struct {
+	Total  int         `json:"total"`
+	Count  int         `json:"count"`
+	Issues []IssueJSON `json:"issues"`
+}
+
+type IssueDetailJSON struct {
+	IssueJSON
+	Links       []LinkJSON       `json:"links,omitempty"`
+	Fields      []FieldJSON      `json:"fields,omitempty"`
+	Attachments []AttachmentJSON `json:"attachments,omitempty"`
+	Comments    []CommentJSON    `json:"comments,omitempty"`
+}
+
+type LinkJSON struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Relation string `json:"relation"`
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Status   string `json:"status"`
+}
+
+type CommentJSON struct {
+	ID      string    `json:"id"`
+	Author  *UserJSON `json:"author"`
+	Body    string    `json:"body"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// ActionJSON describes the outcome of a command that changes a ticket,
+// e.g. a transition, assignment or new comment.
+type ActionJSON struct {
+	Key      string       `json:"key"`
+	Action   string       `json:"action"`
+	Status   string       `json:"status,omitempty"`
+	Assignee string       `json:"assignee,omitempty"`
+	Comment  string       `json:"comment,omitempty"`
+	Fields   []string     `json:"fields,omitempty"`
+	Relation string       `json:"relation,omitempty"`
+	Target   string       `json:"target,omitempty"`
+	Worklog  *WorklogJSON `json:"worklog,omitempty"`
+	Warnings []string     `json:"warnings,omitempty"`
+}
+
+// TimerJSON describes the work timer; only Running is set when it's stopped.
+type TimerJSON struct {
+	Running        bool       `json:"running"`
+	Key            string     `json:"key,omitempty"`
+	Profile        string     `json:"profile,omitempty"`
+	Started        *time.Time `json:"started,omitempty"`
+	ElapsedSeconds int        `json:"elapsedSeconds,omitempty"`
+	RoundedSeconds int        `json:"roundedSeconds,omitempty"`
+}
+
+type CreatedJSON struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
+
+type ErrorJSON struct {
+	Error      string            `json:"error"`
+	Message    string            `json:"message,omitempty"`
+	StatusCode int               `json:"statusCode,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+}
+
+func NewUserJSON(user *api.User) *UserJSON {
+	if user == nil {
+		return nil
+	}
+	return &UserJSON{
+		AccountID:   user.AccountID,
+		DisplayName: user.DisplayName,
+		Email:       user.EmailAddress,
+	}
+}
+
+func NewIssueJSON(issue *api.Issue, jiraURL string) IssueJSON {
+	doc := IssueJSON{
+		ID:          issue.ID,
+		Key:         issue.Key,
+		Summary:     issue.Fields.Summary,
+		Type:        issue.Fields.IssueType.Name,
+		Status:      issue.Fields.Status.Name,
+		Priority:    issue.Fields.Priority.Name,
+		Assignee:    NewUserJSON(issue.Fields.Assignee),
+		Reporter:    NewUserJSON(issue.Fields.Reporter),
+		Project:     issue.Fields.Project.Key,
+		Description: descriptionText(issue),
+		Created:     issue.Fields.Created.Time,
+		Updated:     issue.Fields.Updated.Time,
+	}
+	if jiraURL != "" {
+		doc.URL = fmt.Sprintf("%s/browse/%s", jiraURL, issue.Key)
+	}
+	return doc
+}
+
+func NewCommentJSON(comment *api.Comment) CommentJSON {
+	return CommentJSON{
+		ID:      comment.ID,
+		Author:  NewUserJSON(&comment.Author),
+		Body:    RichTextPlain(comment.Body),
+		Created: comment.Created.Time,
+		Updated: comment.Updated.Time,
+	}
+}
+
+func renderIssueListJSON(results *api.SearchResults) {
+	doc := IssueListJSON{
+		Total:  results.Total,
+		Count:  len(results.Issues),
+		Issues: make([]IssueJSON, 0, len(results.Issues)),
+	}
+	if doc.Total == 0 {
+		doc.Total = doc.Count
+	}
+	for i := range results.Issues {
+		doc.Issues = append(doc.Issues, NewIssueJSON(&results.Issues[i], ""))
+	}
+	PrintJSON(doc)
+}
+
+func renderIssueDetailJSON(issue *api.Issue, jiraURL string, comments []api.Comment, fieldMeta []api.Field) {
+	doc := IssueDetailJSON{IssueJSON: NewIssueJSON(issue, jiraURL)}
+	for _, link := range issue.Fields.IssueLinks {
+		relation, other := link.Relation()
+		if other == nil {
+			continue
+		}
+		doc.Links = append(doc.Links, LinkJSON{
+			ID:       link.ID,
+			Type:     link.Type.Name,
+			Relation: relation,
+			Key:      other.Key,
+			Summary:  other.Fields.Summary,
+			Status:   other.Fields.Status.Name,
+		})
+	}
+	if fieldMeta != nil {
+		doc.Fields = issueFields(issue, fieldMeta)
+	}
+	for i := range issue.Fields.Attachments {
+		doc.Attachments = append(doc.Attachments, NewAttachmentJSON(&issue.Fields.Attachments[i]))
+	}
+	for i := range comments {
+		doc.Comments = append(doc.Comments, NewCommentJSON(&comments[i]))
+	}
+	PrintJSON(doc)
+}
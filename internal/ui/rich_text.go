@@ -0,0 +1,629 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/danielyan21/JiraCLI/internal/adf"
+	"github.com/fatih/color"
+)
+
+// RenderRichText renders a description or comment body for the terminal.
+// body is either an ADF document (API v3) or a wiki markup string (API v2);
+// both are turned into ADF and laid out to width columns. The result keeps
+// lists, code blocks, quotes, panels and tables, and shows link URLs.
+func RenderRichText(body interface{}, width int) string {
+	return richTextRenderer(true).render(body, width)
+}
+
+// RichTextPlain is RenderRichText without colors or wrapping, for JSON output.
+func RichTextPlain(body interface{}) string {
+	return richTextRenderer(false).render(body, 0)
+}
+
+type styleFunc func(a ...interface{}) string
+
+type textRenderer struct {
+	styled bool
+	c      *ColorFuncs
+}
+
+func richTextRenderer(styled bool) *textRenderer {
+	return &textRenderer{styled: styled, c: NewColorFuncs()}
+}
+
+// line is one rendered output line and its visible width (without ANSI codes).
+type line struct {
+	text  string
+	width int
+}
+
+func plainLine(s string) line {
+	return line{text: s, width: utf8.RuneCountInString(s)}
+}
+
+func (r *textRenderer) render(body interface{}, width int) string {
+	doc, err := adf.FromBody(body)
+	if err != nil {
+		return ""
+	}
+
+	lines := r.blocks(doc.Content, width, 0)
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = strings.TrimRight(l.text, " ")
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+func (r *textRenderer) style(f styleFunc, s string) string {
+	if !r.styled || f == nil {
+		return s
+	}
+	return f(s)
+}
+
+// blocks renders block nodes separated by blank lines. depth counts list
+// nesting so bullets can vary by level.
+func (r *textRenderer) blocks(nodes []*adf.Node, width, depth int) []line {
+	var lines []line
+	for i, node := range nodes {
+		rendered := r.block(node, width, depth)
+		if len(rendered) == 0 {
+			continue
+		}
+		// Lists inside list items sit directly under their parent's text.
+		tight := depth > 0 && (node.Type == adf.TypeBulletList || node.Type == adf.TypeOrderedList)
+		if i > 0 && len(lines) > 0 && !tight {
+			lines = append(lines, line{})
+		}
+		lines = append(lines, rendered...)
+	}
+	return lines
+}
+
+func (r *textRenderer) block(node *adf.Node, width, depth int) []line {
+	switch node.Type {
+	case adf.TypeParagraph:
+		return r.wrap(r.inline(node.Content, nil), width)
+
+	case adf.TypeHeading:
+		level, _ := node.AttrInt("level")
+		heading := r.c.Bold
+		if level <= 2 {
+			heading = color.New(color.Bold, color.FgCyan, color.Underline).SprintFunc()
+		}
+		return r.wrap(r.inline(node.Content, heading), width)
+
+	case adf.TypeBulletList:
+		bullets := []string{"•", "◦", "▪"}
+		bullet := bullets[depth%len(bullets)]
+		var lines []line
+		for _, item := range node.Content {
+			lines = append(lines, r.listItem(item, bullet+" ", width, depth)...)
+		}
+		return lines
+
+	case adf.TypeOrderedList:
+		start := 1
+		if order, ok := node.AttrInt("order"); ok {
+			start = order
+		}
+		numberWidth := len(strconv.Itoa(start + len(node.Content) - 1))
+		var lines []line
+		for i, item := range node.Content {
+			marker := fmt.Sprintf("%*d. ", numberWidth, start+i)
+			lines = append(lines, r.listItem(item, marker, width, depth)...)
+		}
+		return lines
+
+	case adf.TypeTaskList:
+		var lines []line
+		for _, item := range node.Content {
+			box := "☐ "
+			if item.AttrString("state") == "DONE" {
+				box = "☑ "
+			}
+			lines = append(lines, r.listItem(item, box, width, depth)...)
+		}
+		return lines
+
+	case adf.TypeDecisionList:
+		var lines []line
+		for _, item := range node.Content {
+			lines = append(lines, r.listItem(item, "◆ ", width, depth)...)
+		}
+		return lines
+
+	case adf.TypeCodeBlock:
+		return r.codeBlock(node)
+
+	case adf.TypeBlockquote:
+		return r.prefixed(r.blocks(node.Content, width-2, depth), r.style(r.c.Gray, "│ "), 2)
+
+	case adf.TypePanel:
+		return r.panel(node, width, depth)
+
+	case adf.TypeRule:
+		ruleWidth := width
+		if ruleWidth <= 0 {
+			ruleWidth = 40
+		}
+		return []line{{text: r.style(r.c.Gray, strings.Repeat("─", ruleWidth)), width: ruleWidth}}
+
+	case adf.TypeTable:
+		return r.table(node, width)
+
+	case adf.TypeExpand, adf.TypeNestedExpand:
+		title := node.AttrString("title")
+		if title == "" {
+			title = "Details"
+		}
+		header := r.wrap([]span{{text: "▸ " + title, style: r.c.Bold}}, width)
+		return append(header, r.prefixed(r.blocks(node.Content, width-2, depth), "  ", 2)...)
+
+	case adf.TypeMediaSingle, adf.TypeMediaGroup:
+		var lines []line
+		for _, media := range node.Content {
+			lines = append(lines, r.wrap(r.inline([]*adf.Node{media}, nil), width)...)
+		}
+		return lines
+
+	case adf.TypeBlockCard, adf.TypeEmbedCard:
+		return r.wrap(r.inline([]*adf.Node{{Type: adf.TypeInlineCard, Attrs: node.Attrs}}, nil), width)
+	}
+
+	// Unknown blocks: render whatever they contain.
+	if isInlineContent(node.Content) {
+		return r.wrap(r.inline(node.Content, nil), width)
+	}
+	return r.blocks(node.Content, width, depth)
+}
+
+// listItem renders an item's content with marker on its first line and
+// matching indentation on the rest.
+func (r *textRenderer) listItem(item *adf.Node, marker string, width, depth int) []line {
+	markerWidth := utf8.RuneCountInString(marker)
+
+	var content []line
+	if isInlineContent(item.Content) {
+		// taskItem and decisionItem hold inline nodes directly.
+		content = r.wrap(r.inline(item.Content, nil), width-markerWidth)
+	} else {
+		content = r.blocks(item.Content, width-markerWidth, depth+1)
+	}
+	if len(content) == 0 {
+		content = []line{{}}
+	}
+
+	indent := strings.Repeat(" ", markerWidth)
+	lines := make([]line, len(content))
+	for i, l := range content {
+		prefix := indent
+		if i == 0 {
+			prefix = r.style(r.c.Gray, marker)
+		}
+		lines[i] = line{text: prefix + l.text, width: markerWidth + l.width}
+	}
+	return lines
+}
+
+func (r *textRenderer) prefixed(lines []line, prefix string, prefixWidth int) []line {
+	out := make([]line, len(lines))
+	for i, l := range lines {
+		out[i] = line{text: prefix + l.text, width: prefixWidth + l.width}
+	}
+	return out
+}
+
+// codeBlock shows code verbatim (no wrapping or whitespace changes) behind
+// a gutter, with the language as a label.
+func (r *textRenderer) codeBlock(node *adf.Node) []line {
+	var lines []line
+	if language := node.AttrString("language"); language != "" {
+		lines = append(lines, line{text: r.style(r.c.Gray, "┌ "+language), width: 2 + utf8.RuneCountInString(language)})
+	}
+	gutter := r.style(r.c.Gray, "│ ")
+	for _, code := range strings.Split(plainContent(node), "\n") {
+		code = strings.ReplaceAll(code, "\t", "    ")
+		lines = append(lines, line{text: gutter + r.style(r.c.Cyan, code), width: 2 + utf8.RuneCountInString(code)})
+	}
+	return lines
+}
+
+var panelStyles = map[string]struct {
+	icon  string
+	color color.Attribute
+}{
+	"info":    {"ℹ", color.FgBlue},
+	"note":    {"✎", color.FgMagenta},
+	"warning": {"⚠", color.FgYellow},
+	"error":   {"✖", color.FgRed},
+	"success": {"✔", color.FgGreen},
+}
+
+func (r *textRenderer) panel(node *adf.Node, width, depth int) []line {
+	panelType := node.AttrString("panelType")
+	style, ok := panelStyles[panelType]
+	if !ok {
+		style = panelStyles["info"]
+		panelType = "info"
+	}
+	paint := color.New(style.color).SprintFunc()
+
+	label := style.icon + " " + strings.ToUpper(panelType[:1]) + panelType[1:]
+	lines := []line{{text: r.style(paint, "┃ ") + r.style(color.New(style.color, color.Bold).SprintFunc(), label), width: 2 + utf8.RuneCountInString(label)}}
+	return append(lines, r.prefixed(r.blocks(node.Content, width-2, depth), r.style(paint, "┃ "), 2)...)
+}
+
+// table lays cells out in columns, shrinking the widest columns (and
+// wrapping their text) when the table would overflow width.
+func (r *textRenderer) table(node *adf.Node, width int) []line {
+	type cell struct {
+		spans  []span
+		header bool
+	}
+	var rows [][]cell
+	columns := 0
+	for _, row := range node.Content {
+		var cells []cell
+		for _, c := range row.Content {
+			var spans []span
+			for i, child := range c.Content {
+				if i > 0 {
+					spans = append(spans, span{text: " "})
+				}
+				spans = append(spans, r.inline(flattenInline(child), nil)...)
+			}
+			cells = append(cells, cell{spans: spans, header: c.Type == adf.TypeTableHeader})
+		}
+		// A row without cells (e.g. a wiki line holding only "|") has nothing to show.
+		if len(cells) == 0 {
+			continue
+		}
+		rows = append(rows, cells)
+		if len(cells) > columns {
+			columns = len(cells)
+		}
+	}
+	if columns == 0 {
+		return nil
+	}
+
+	colWidths := make([]int, columns)
+	for _, row := range rows {
+		for i, c := range row {
+			if w := spansWidth(c.spans); w > colWidths[i] {
+				colWidths[i] = w
+			}
+		}
+	}
+
+	// Borders take 3 columns per cell plus one.
+	if width > 0 {
+		available := width - (3*columns + 1)
+		for total(colWidths) > available && available > columns*4 {
+			widest := 0
+			for i, w := range colWidths {
+				if w > colWidths[widest] {
+					widest = i
+				}
+			}
+			colWidths[widest]--
+		}
+	}
+
+	border := func(left, mid, right string) line {
+		parts := make([]string, columns)
+		for i, w := range colWidths {
+			parts[i] = strings.Repeat("─", w+2)
+		}
+		text := left + strings.Join(parts, mid) + right
+		return line{text: r.style(r.c.Gray, text), width: utf8.RuneCountInString(text)}
+	}
+
+	bar := r.style(r.c.Gray, "│")
+	lines := []line{border("┌", "┬", "┐")}
+	for rowIndex, row := range rows {
+		cellLines := make([][]line, columns)
+		height := 1
+		for i := 0; i < columns; i++ {
+			if i < len(row) {
+				spans := row[i].spans
+				if row[i].header {
+					spans = restyle(spans, r.c.Bold)
+				}
+				cellLines[i] = r.wrap(spans, colWidths[i])
+			}
+			if len(cellLines[i]) > height {
+				height = len(cellLines[i])
+			}
+		}
+
+		for h := 0; h < height; h++ {
+			var b strings.Builder
+			b.WriteString(bar)
+			for i := 0; i < columns; i++ {
+				var l line
+				if h < len(cellLines[i]) {
+					l = cellLines[i][h]
+				}
+				b.WriteString(" " + l.text + strings.Repeat(" ", max(colWidths[i]-l.width, 0)) + " ")
+				b.WriteString(bar)
+			}
+			lines = append(lines, line{text: b.String(), width: total(colWidths) + 3*columns + 1})
+		}
+
+		if rowIndex == 0 && len(rows) > 1 && len(row) > 0 && row[0].header {
+			lines = append(lines, border("├", "┼", "┤"))
+		}
+	}
+	return append(lines, border("└", "┴", "┘"))
+}
+
+func total(widths []int) int {
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+	return sum
+}
+
+// span is a run of text with a single style; hard breaks are "\n".
+type span struct {
+	text  string
+	style styleFunc
+}
+
+// inline flattens inline nodes into styled spans.
+func (r *textRenderer) inline(nodes []*adf.Node, base styleFunc) []span {
+	var spans []span
+	for _, node := range nodes {
+		switch node.Type {
+		case adf.TypeText:
+			spans = append(spans, r.textSpans(node, base)...)
+
+		case adf.TypeHardBreak:
+			spans = append(spans, span{text: "\n"})
+
+		case adf.TypeMention:
+			text := node.AttrString("text")
+			if text == "" {
+				text = "@" + node.AttrString("id")
+			}
+			if !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			spans = append(spans, span{text: text, style: r.c.Yellow})
+
+		case adf.TypeEmoji:
+			text := node.AttrString("text")
+			if text == "" {
+				text = node.AttrString("shortName")
+			}
+			spans = append(spans, span{text: text})
+
+		case adf.TypeInlineCard:
+			url := node.AttrString("url")
+			spans = append(spans, span{text: url, style: linkStyle})
+
+		case adf.TypeStatus:
+			spans = append(spans, span{text: "[" + strings.ToUpper(node.AttrString("text")) + "]", style: r.statusStyle(node.AttrString("color"))})
+
+		case adf.TypeDate:
+			text := node.AttrString("timestamp")
+			if ms, err := strconv.ParseInt(text, 10, 64); err == nil {
+				text = time.UnixMilli(ms).UTC().Format("2006-01-02")
+			}
+			spans = append(spans, span{text: text, style: base})
+
+		case adf.TypeMedia, adf.TypeMediaSingle:
+			name := node.AttrString("alt")
+			if name == "" && len(node.Content) > 0 {
+				name = node.Content[0].AttrString("alt")
+			}
+			if name == "" {
+				name = "media"
+			}
+			spans = append(spans, span{text: "[attachment: " + name + "]", style: r.c.Gray})
+
+		default:
+			spans = append(spans, r.inline(node.Content, base)...)
+		}
+	}
+	return spans
+}
+
+var linkStyle = color.New(color.FgBlue, color.Underline).SprintFunc()
+
+// textSpans applies a text node's marks. Links show their URL after the
+// text unless the text already is the URL.
+func (r *textRenderer) textSpans(node *adf.Node, base styleFunc) []span {
+	attrs := []color.Attribute{}
+	var href string
+	for _, mark := range node.Marks {
+		switch mark.Type {
+		case adf.MarkStrong:
+			attrs = append(attrs, color.Bold)
+		case adf.MarkEm:
+			attrs = append(attrs, color.Italic)
+		case adf.MarkUnderline:
+			attrs = append(attrs, color.Underline)
+		case adf.MarkStrike:
+			attrs = append(attrs, color.CrossedOut)
+		case adf.MarkCode:
+			attrs = append(attrs, color.FgCyan)
+		case adf.MarkLink:
+			href = mark.AttrString("href")
+			attrs = append(attrs, color.FgBlue, color.Underline)
+		}
+	}
+
+	style := base
+	if len(attrs) > 0 {
+		style = color.New(attrs...).SprintFunc()
+	}
+	spans := []span{{text: node.Text, style: style}}
+	if href != "" && href != node.Text {
+		spans = append(spans, span{text: " (" + href + ")", style: r.c.Gray})
+	}
+	return spans
+}
+
+func (r *textRenderer) statusStyle(statusColor string) styleFunc {
+	switch statusColor {
+	case "green":
+		return r.c.Green
+	case "yellow":
+		return r.c.Yellow
+	case "red":
+		return r.c.Red
+	case "blue":
+		return r.c.Blue
+	}
+	return r.c.Gray
+}
+
+func restyle(spans []span, style styleFunc) []span {
+	out := make([]span, len(spans))
+	for i, s := range spans {
+		out[i] = span{text: s.text, style: style}
+	}
+	return out
+}
+
+func spansWidth(spans []span) int {
+	w := 0
+	for _, s := range spans {
+		w += utf8.RuneCountInString(s.text)
+	}
+	return w
+}
+
+// word is a run of non-space text, possibly made of several styled pieces
+// (e.g. a bold word followed by a plain comma).
+type word struct {
+	pieces []span
+	width  int
+}
+
+// wrap lays spans out into lines of at most width visible columns, breaking
+// at spaces and at hard breaks. A width of 0 or less means no wrapping.
+func (r *textRenderer) wrap(spans []span, width int) []line {
+	var words []*word // nil entries mark hard breaks
+	var current *word
+
+	endWord := func() {
+		if current != nil {
+			words = append(words, current)
+			current = nil
+		}
+	}
+
+	for _, s := range spans {
+		var run strings.Builder
+		flushRun := func() {
+			if run.Len() == 0 {
+				return
+			}
+			if current == nil {
+				current = &word{}
+			}
+			current.pieces = append(current.pieces, span{text: run.String(), style: s.style})
+			current.width += utf8.RuneCountInString(run.String())
+			run.Reset()
+		}
+		for _, ch := range s.text {
+			switch ch {
+			case ' ', '\t':
+				flushRun()
+				endWord()
+			case '\n':
+				flushRun()
+				endWord()
+				words = append(words, nil)
+			default:
+				run.WriteRune(ch)
+			}
+		}
+		flushRun()
+	}
+	endWord()
+
+	var lines []line
+	var b strings.Builder
+	lineWidth := 0
+	started := false
+
+	emit := func() {
+		lines = append(lines, line{text: b.String(), width: lineWidth})
+		b.Reset()
+		lineWidth = 0
+		started = false
+	}
+
+	for _, w := range words {
+		if w == nil {
+			emit()
+			continue
+		}
+		if started && width > 0 && lineWidth+1+w.width > width {
+			emit()
+		}
+		if started {
+			b.WriteString(" ")
+			lineWidth++
+		}
+		for _, p := range w.pieces {
+			b.WriteString(r.style(p.style, p.text))
+		}
+		lineWidth += w.width
+		started = true
+	}
+	if started || len(lines) == 0 {
+		emit()
+	}
+	return lines
+}
+
+func isInlineContent(nodes []*adf.Node) bool {
+	for _, n := range nodes {
+		switch n.Type {
+		case adf.TypeText, adf.TypeHardBreak, adf.TypeMention, adf.TypeEmoji,
+			adf.TypeInlineCard, adf.TypeStatus, adf.TypeDate:
+		default:
+			return false
+		}
+	}
+	return len(nodes) > 0
+}
+
+// flattenInline returns the inline nodes of a block, for table cells which
+// are rendered on as few lines as possible.
+func flattenInline(node *adf.Node) []*adf.Node {
+	if isInlineContent(node.Content) || node.Type == adf.TypeParagraph || node.Type == adf.TypeHeading {
+		return node.Content
+	}
+	var nodes []*adf.Node
+	for i, child := range node.Content {
+		if i > 0 {
+			nodes = append(nodes, &adf.Node{Type: adf.TypeText, Text: " "})
+		}
+		nodes = append(nodes, flattenInline(child)...)
+	}
+	return nodes
+}
+
+func plainContent(node *adf.Node) string {
+	if node.Type == adf.TypeText {
+		return node.Text
+	}
+	var b strings.Builder
+	for _, child := range node.Content {
+		b.WriteString(plainContent(child))
+	}
+	return b.String()
+}
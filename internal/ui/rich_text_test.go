@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderRichTextTableWithEmptyRow(t *testing.T) {
+	tests := []struct {
+		name string
+		body interface{}
+		want string
+	}{
+		{"wiki bar only", "|", ""},
+		{"wiki bar before table", "|\n||a||b||\n|1|2|", "a"},
+		{"adf empty first row", map[string]interface{}{
+			"type":    "doc",
+			"version": 1,
+			"content": []interface{}{
+				map[string]interface{}{"type": "table", "content": []interface{}{
+					map[string]interface{}{"type": "tableRow"},
+					map[string]interface{}{"type": "tableRow", "content": []interface{}{
+						map[string]interface{}{"type": "tableHeader", "content": []interface{}{
+							map[string]interface{}{"type": "paragraph", "content": []interface{}{
+								map[string]interface{}{"type": "text", "text": "head"},
+							}},
+						}},
+					}},
+				}},
+			},
+		}, "head"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RichTextPlain(tt.body)
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("RichTextPlain(%q) = %q, want it to contain %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+)
+
+type SprintJSON struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	Goal      string     `json:"goal,omitempty"`
+	StartDate *time.Time `json:"startDate,omitempty"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+	BoardID   int        `json:"boardId,omitempty"`
+}
+
+type SprintIssuesJSON struct {
+	Sprint SprintJSON    `json:"sprint"`
+	Issues IssueListJSON `json:"issues"`
+}
+
+func NewSprintJSON(sprint *api.Sprint) SprintJSON {
+	doc := SprintJSON{
+		ID:      sprint.ID,
+		Name:    sprint.Name,
+		State:   sprint.State,
+		Goal:    sprint.Goal,
+		BoardID: sprint.OriginBoardID,
+	}
+	if !sprint.StartDate.IsZero() {
+		doc.StartDate = &sprint.StartDate.Time
+	}
+	if !sprint.EndDate.IsZero() {
+		doc.EndDate = &sprint.EndDate.Time
+	}
+	return doc
+}
+
+func RenderSprints(sprints []api.Sprint) {
+	if jsonOutput {
+		docs := make([]SprintJSON, 0, len(sprints))
+		for i := range sprints {
+			docs = append(docs, NewSprintJSON(&sprints[i]))
+		}
+		PrintJSON(docs)
+		return
+	}
+
+	if len(sprints) == 0 {
+		fmt.Println("\nNo sprints found.")
+		return
+	}
+
+	c := NewColorFuncs()
+	fmt.Printf("\n%-8s %-8s %-25s %s\n", "ID", "STATE", "DATES", "NAME")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, sprint := range sprints {
+		fmt.Printf("%s %s %s %s\n",
+			c.Cyan(fmt.Sprintf("%-8d", sprint.ID)),
+			sprintStateColor(sprint.State, c)(fmt.Sprintf("%-8s", sprint.State)),
+			c.Gray(fmt.Sprintf("%-25s", sprintDates(&sprint))),
+			sprint.Name,
+		)
+	}
+}
+
+// RenderSprintIssues prints a sprint's header followed by its issues.
+func RenderSprintIssues(sprint *api.Sprint, results *api.SearchResults) {
+	if jsonOutput {
+		doc := SprintIssuesJSON{Sprint: NewSprintJSON(sprint), Issues: IssueListJSON{
+			Total:  results.Total,
+			Count:  len(results.Issues),
+			Issues: make([]IssueJSON, 0, len(results.Issues)),
+		}}
+		for i := range results.Issues {
+			doc.Issues.Issues = append(doc.Issues.Issues, NewIssueJSON(&results.Issues[i], ""))
+		}
+		PrintJSON(doc)
+		return
+	}
+
+	c := NewColorFuncs()
+	fmt.Printf("\n%s %s\n", c.Bold(sprint.Name), sprintStateColor(sprint.State, c)("("+sprint.State+")"))
+	if dates := sprintDates(sprint); dates != "" {
+		line := dates
+		if sprint.State == api.SprintActive && !sprint.EndDate.IsZero() {
+			days := int(time.Until(sprint.EndDate.Time).Hours() / 24)
+			line += fmt.Sprintf(", %d day(s) left", days)
+		}
+		fmt.Printf("%s\n", c.Gray(line))
+	}
+	if sprint.Goal != "" {
+		fmt.Printf("%s %s\n", c.Bold("Goal:"), sprint.Goal)
+	}
+	RenderIssueList(results)
+}
+
+func sprintDates(sprint *api.Sprint) string {
+	if sprint.StartDate.IsZero() {
+		return ""
+	}
+	dates := sprint.StartDate.Local().Format("2006-01-02")
+	if !sprint.EndDate.IsZero() {
+		dates += " - " + sprint.EndDate.Local().Format("2006-01-02")
+	}
+	return dates
+}
+
+func sprintStateColor(state string, c *ColorFuncs) func(a ...interface{}) string {
+	switch state {
+	case api.SprintActive:
+		return c.Green
+	case api.SprintFuture:
+		return c.Blue
+	}
+	return c.Gray
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danielyan21/JiraCLI/internal/api"
+)
+
+type WorklogJSON struct {
+	ID               string    `json:"id"`
+	Author           *UserJSON `json:"author"`
+	Started          time.Time `json:"started"`
+	TimeSpent        string    `json:"timeSpent"`
+	TimeSpentSeconds int       `json:"timeSpentSeconds"`
+	Comment          string    `json:"comment,omitempty"`
+}
+
+type WorklogListJSON struct {
+	Key          string        `json:"key"`
+	TotalSeconds int           `json:"totalSeconds"`
+	Worklogs     []WorklogJSON `json:"worklogs"`
+}
+
+// TimesheetJSON is the time a user logged per issue per day over a date
+// range. Days are YYYY-MM-DD dates in the local time zone.
+type TimesheetJSON struct {
+	User         *UserJSON            `json:"user"`
+	From         string               `json:"from"`
+	To           string               `json:"to"`
+	Days         []string             `json:"days"`
+	Issues       []TimesheetIssueJSON `json:"issues"`
+	DayTotals    map[string]int       `json:"dayTotals"`
+	TotalSeconds int                  `json:"totalSeconds"`
+}
+
+type TimesheetIssueJSON struct {
+	Key          string         `json:"key"`
+	Summary      string         `json:"summary"`
+	Days         map[string]int `json:"days"`
+	TotalSeconds int            `json:"totalSeconds"`
+}
+
+func NewWorklogJSON(worklog *api.Worklog) WorklogJSON {
+	return WorklogJSON{
+		ID:               worklog.ID,
+		Author:           NewUserJSON(worklog.Author),
+		Started:          worklog.Started.Time,
+		TimeSpent:        worklog.TimeSpent,
+		TimeSpentSeconds: worklog.TimeSpentSeconds,
+		Comment:          strings.TrimSpace(RichTextPlain(worklog.Comment)),
+	}
+}
+
+// FormatDuration renders seconds as hours and minutes, e.g. "2h 15m".
+// Days are not used because their length is configured per Jira instance.
+func FormatDuration(seconds int) string {
+	minutes := (seconds + 30) / 60
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh %dm", h, m)
+}
+
+func RenderWorklogs(issueKey string, worklogs []api.Worklog) {
+	doc := WorklogListJSON{Key: issueKey, Worklogs: make([]WorklogJSON, 0, len(worklogs))}
+	for i := range worklogs {
+		doc.Worklogs = append(doc.Worklogs, NewWorklogJSON(&worklogs[i]))
+		doc.TotalSeconds += worklogs[i].TimeSpentSeconds
+	}
+	if jsonOutput {
+		PrintJSON(doc)
+		return
+	}
+
+	if len(worklogs) == 0 {
+		fmt.Printf("\nNo time logged on %s.\n", issueKey)
+		return
+	}
+
+	c := NewColorFuncs()
+	fmt.Printf("\n%s\n\n", c.Bold(fmt.Sprintf("Worklogs on %s:", issueKey)))
+	fmt.Printf("%-10s %-16s %-8s %-20s %s\n", "ID", "STARTED", "TIME", "AUTHOR", "COMMENT")
+	fmt.Println(strings.Repeat("-", 80))
+
+	for _, worklog := range doc.Worklogs {
+		author := ""
+		if worklog.Author != nil {
+			author = worklog.Author.DisplayName
+		}
+		comment := strings.Join(strings.Fields(worklog.Comment), " ")
+		fmt.Printf("%s %s %s %s %s\n",
+			c.Cyan(fmt.Sprintf("%-10s", worklog.ID)),
+			c.Gray(worklog.Started.Local().Format("2006-01-02 15:04")),
+			fmt.Sprintf("%-8s", FormatDuration(worklog.TimeSpentSeconds)),
+			c.Yellow(fmt.Sprintf("%-20s", Truncate(author, 20))),
+			Truncate(comment, 40),
+		)
+	}
+
+	fmt.Printf("\n%s\n", c.Green(fmt.Sprintf("Total: %s in %d worklog(s)", FormatDuration(doc.TotalSeconds), len(worklogs))))
+}
+
+func RenderTimesheet(doc *TimesheetJSON) {
+	if jsonOutput {
+		PrintJSON(doc)
+		return
+	}
+
+	c := NewColorFuncs()
+	fmt.Printf("\n%s\n\n", c.Bold(fmt.Sprintf("Timesheet %s to %s:", doc.From, doc.To)))
+	if len(doc.Issues) == 0 {
+		fmt.Println("No time logged.")
+		return
+	}
+
+	const cell = "%-8s"
+	header := fmt.Sprintf("%-10s ", "KEY")
+	for _, day := range doc.Days {
+		date, _ := time.Parse("2006-01-02", day)
+		header += fmt.Sprintf(cell, date.Format("Mon 02"))
+	}
+	header += fmt.Sprintf(cell, "TOTAL") + " SUMMARY"
+	fmt.Println(header)
+	fmt.Println(strings.Repeat("-", len(header)+20))
+
+	for _, issue := range doc.Issues {
+		row := c.Cyan(fmt.Sprintf("%-10s ", issue.Key))
+		for _, day := range doc.Days {
+			row += durationCell(issue.Days[day], cell, c)
+		}
+		row += c.Bold(fmt.Sprintf(cell, FormatDuration(issue.TotalSeconds)))
+		fmt.Printf("%s %s\n", row, Truncate(issue.Summary, 40))
+	}
+
+	fmt.Println(strings.Repeat("-", len(header)+20))
+	row := c.Bold(fmt.Sprintf("%-10s ", "TOTAL"))
+	for _, day := range doc.Days {
+		row += durationCell(doc.DayTotals[day], cell, c)
+	}
+	fmt.Printf("%s%s\n", row, c.Green(fmt.Sprintf(cell, FormatDuration(doc.TotalSeconds))))
+}
+
+func durationCell(seconds int, layout string, c *ColorFuncs) string {
+	if seconds == 0 {
+		return c.Gray(fmt.Sprintf(layout, "-"))
+	}
+	return fmt.Sprintf(layout, FormatDuration(seconds))
+}